@@ -1,20 +1,32 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"log/slog"
 	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
 
 	_ "github.com/yehezkiel1086/go-gin-hexa-archi/docs"
 
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/config"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/event"
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/handler"
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/logger"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/spam"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/storage/memory"
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/storage/postgres"
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/storage/postgres/repository"
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/storage/redis"
-	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/webhook"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/worker"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/service"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/util"
 )
 
 func handleError(err error, msg string) {
@@ -24,6 +36,16 @@ func handleError(err error, msg string) {
 	}
 }
 
+func newMemoryCache(conf *config.Cache) *memory.Cache {
+	maxEntries, err := strconv.Atoi(conf.MaxEntries)
+	handleError(err, "invalid cache max entries")
+
+	maxBytes, err := strconv.Atoi(conf.MaxBytes)
+	handleError(err, "invalid cache max bytes")
+
+	return memory.NewCache(maxEntries, maxBytes)
+}
+
 //	@title			Go Gin Hexa Archi
 //	@version		1.0
 //	@description	Hexagonal architecture built with Go.
@@ -50,42 +72,208 @@ func main() {
 	// init logger
 	logger.Set(conf.App)
 
-	// init context
-	ctx := context.Background()
+	// init context (cancelled on shutdown signals)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	// init db connection
 	db, err := postgres.New(ctx, conf.DB)
 	handleError(err, "unable to connect with postgres db")
 	slog.Info("postgres db connected successfully", "db", conf.DB.Host+":"+conf.DB.Port)
 
-	// migrate dbs
-	err = db.Migrate(&domain.User{}, &domain.Category{}, &domain.Post{})
+	// run `migrate up|down|status` and exit instead of serving
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		handleError(runMigrate(ctx, db, os.Args[2:]), "migration failed")
+		return
+	}
+
+	// apply pending migrations, replicas starting at once wait on the migration lock
+	applied, err := db.MigrateUp(ctx)
 	handleError(err, "migration failed")
-	slog.Info("dbs migrated successfully")
+	slog.Info("dbs migrated successfully", "applied", len(applied))
 
 	// init redis connection
-	cache, err := redis.New(ctx, conf.Redis)
+	rdb, err := redis.New(ctx, conf.Redis, conf.App.Name+":")
 	handleError(err, "unable to connect with redis")
+	defer rdb.Close()
+
+	slog.Info("redis initialized successfully", "mode", cmp.Or(conf.Redis.Mode, "standalone"), "redis", cmp.Or(conf.Redis.Addrs, conf.Redis.Host+":"+conf.Redis.Port))
+
+	// services fall back to the db while redis is unavailable
+	breakerThreshold, err := strconv.Atoi(conf.Redis.BreakerThreshold)
+	handleError(err, "invalid redis breaker threshold")
+
+	probeInterval, err := strconv.Atoi(conf.Redis.ProbeInterval)
+	handleError(err, "invalid redis probe interval")
+
+	cachePolicy, err := util.NewCachePolicy(conf.Cache)
+	handleError(err, "invalid cache config")
+
+	// values cached by another version or with another codec are never read
+	cacheVersion := conf.App.Version + "-" + cachePolicy.Codec().Name()
+
+	// the version's values are flushed if invalidations are lost while redis is down
+	failOpenCache := redis.NewFailOpenCache(rdb, breakerThreshold, time.Duration(probeInterval)*time.Second, conf.App.Name+":"+cacheVersion+":")
+
+	// init cache
+	var cache port.CacheRepository = failOpenCache
+	switch conf.Cache.Driver {
+	case "memory":
+		cache = newMemoryCache(conf.Cache)
+	case "tiered":
+		l1TTL, err := strconv.Atoi(conf.Cache.L1TTL)
+		handleError(err, "invalid cache l1 ttl")
+
+		cache = memory.NewTieredCache(newMemoryCache(conf.Cache), failOpenCache, rdb, time.Duration(l1TTL)*time.Second)
+	}
+
+	cache = util.NewNamespacedCache(cache, conf.App.Name, cacheVersion)
 	defer cache.Close()
 
-	slog.Info("redis connected successfully", "redis", conf.Redis.Host+":"+conf.Redis.Port)
+	slog.Info("cache initialized successfully", "driver", conf.Cache.Driver, "version", cacheVersion)
+
+	// background workers run until ctx is cancelled, shutdown waits for them
+	var workers sync.WaitGroup
+	runWorker := func(start func(ctx context.Context)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			start(ctx)
+		}()
+	}
 
 	// dependency injections
+	// events are stored in the outbox and relayed to the handlers subscribed to the dispatcher
+	eventDispatcher := event.NewMemoryBus(rdb)
+	outboxRepo := repository.NewOutboxRepository(db)
+	eventBus := event.NewOutboxBus(outboxRepo, eventDispatcher)
+
+	var eventPublisher port.EventPublisher = eventDispatcher
+	if conf.Event.Publisher == "redis" {
+		streamMaxLength, err := strconv.ParseInt(conf.Event.StreamMaxLength, 10, 64)
+		handleError(err, "invalid event stream max length")
+
+		eventStream := redis.NewEventStream(rdb, streamMaxLength)
+		runWorker(func(ctx context.Context) { eventStream.Consume(ctx, eventDispatcher) })
+		eventPublisher = eventStream
+	}
+
+	outboxSvc := service.NewOutboxService(outboxRepo, eventPublisher)
+
 	userRepo := repository.NewUserRepository(db)
-	userSvc := service.NewUserService(userRepo, cache)
+	followRepo := repository.NewFollowRepository(db)
+	postRepo := repository.NewPostRepository(db)
+	commentRepo := repository.NewCommentRepository(db)
+	bookmarkRepo := repository.NewBookmarkRepository(db)
+	userSvc := service.NewUserService(userRepo, postRepo, commentRepo, followRepo, bookmarkRepo, failOpenCache, db, eventBus, cache, cachePolicy)
 	userHandler := handler.NewUserHandler(userSvc)
 
 	authSvc := service.NewAuthService(conf.JWT, userRepo)
 	authHandler := handler.NewAuthHandler(conf.JWT, authSvc)
 
 	categoryRepo := repository.NewCategoryRepository(db)
-	categorySvc := service.NewCategoryService(categoryRepo, cache)
+	categorySvc := service.NewCategoryService(categoryRepo, db, eventBus, cache, cachePolicy)
 	categoryHandler := handler.NewCategoryHandler(categorySvc)
 
-	postRepo := repository.NewPostRepository(db)
-	postSvc := service.NewPostService(postRepo, cache)
+	postRevisionRepo := repository.NewPostRevisionRepository(db)
+	slugRepo := repository.NewSlugRepository(db)
+	slugSvc := service.NewSlugService(slugRepo)
+
+	tagRepo := repository.NewTagRepository(db)
+	tagSvc := service.NewTagService(tagRepo, cache, cachePolicy)
+	tagHandler := handler.NewTagHandler(tagSvc)
+
+	reactionRepo := repository.NewReactionRepository(db)
+	reactionSvc := service.NewReactionService(reactionRepo, postRepo, cache)
+	reactionHandler := handler.NewReactionHandler(reactionSvc)
+
+	bookmarkSvc := service.NewBookmarkService(bookmarkRepo, postRepo)
+	bookmarkHandler := handler.NewBookmarkHandler(bookmarkSvc)
+
+	followSvc := service.NewFollowService(followRepo, userRepo, failOpenCache, db, eventBus, cache)
+	followHandler := handler.NewFollowHandler(followSvc)
+
+	fanOutLimit, err := strconv.ParseInt(conf.Feed.FanOutLimit, 10, 64)
+	handleError(err, "invalid feed fan-out limit")
+
+	feedMaxLength, err := strconv.ParseInt(conf.Feed.MaxLength, 10, 64)
+	handleError(err, "invalid feed max length")
+
+	feedSvc := service.NewFeedService(fanOutLimit, feedMaxLength, followRepo, postRepo, failOpenCache)
+	feedHandler := handler.NewFeedHandler(feedSvc)
+
+	postSvc := service.NewPostService(postRepo, postRevisionRepo, bookmarkRepo, slugSvc, tagSvc, reactionSvc, feedSvc, db, eventBus, cache, cachePolicy)
 	postHandler := handler.NewPostHandler(postSvc)
 
+	editWindow, err := strconv.Atoi(conf.Comment.EditWindow)
+	handleError(err, "invalid comment edit window")
+
+	autoTrustAfter, err := strconv.Atoi(conf.Comment.AutoTrustAfter)
+	handleError(err, "invalid comment auto trust after")
+
+	spamChecker := spam.New(conf.Comment, rdb)
+	commentSvc := service.NewCommentService(time.Duration(editWindow)*time.Minute, autoTrustAfter, commentRepo, postRepo, userRepo, spamChecker, db, eventBus, cache, cachePolicy)
+	commentHandler := handler.NewCommentHandler(commentSvc)
+
+	liveCommentSvc := service.NewLiveCommentService(commentRepo, failOpenCache)
+	liveCommentHandler := handler.NewLiveCommentHandler(conf.HTTP, conf.JWT, liveCommentSvc)
+	eventBus.Subscribe(liveCommentSvc.HandleEvent)
+
+	streamHistorySize, err := strconv.ParseInt(conf.Stream.HistorySize, 10, 64)
+	handleError(err, "invalid stream history size")
+
+	streamHeartbeat, err := strconv.Atoi(conf.Stream.HeartbeatInterval)
+	handleError(err, "invalid stream heartbeat interval")
+
+	broker := redis.NewBroker(rdb, streamHistorySize)
+	defer broker.Close()
+
+	streamSvc := service.NewStreamService(broker)
+	streamHandler := handler.NewStreamHandler(time.Duration(streamHeartbeat)*time.Second, streamSvc)
+	eventBus.Subscribe(streamSvc.HandleEvent)
+
+	notificationRepo := repository.NewNotificationRepository(db)
+	notificationSvc := service.NewNotificationService(notificationRepo, userRepo, streamSvc, cache, cachePolicy)
+	notificationHandler := handler.NewNotificationHandler(notificationSvc)
+	eventBus.Subscribe(notificationSvc.HandleEvent)
+
+	webhookTimeout, err := strconv.Atoi(conf.Webhook.Timeout)
+	handleError(err, "invalid webhook timeout")
+
+	webhookMaxAttempts, err := strconv.Atoi(conf.Webhook.MaxAttempts)
+	handleError(err, "invalid webhook max attempts")
+
+	webhookRepo := repository.NewWebhookRepository(db)
+	webhookSender := webhook.NewHTTPSender(time.Duration(webhookTimeout) * time.Second)
+	webhookSvc := service.NewWebhookService(webhookMaxAttempts, webhookRepo, webhookSender)
+	webhookHandler := handler.NewWebhookHandler(webhookSvc)
+	eventBus.Subscribe(webhookSvc.HandleEvent)
+
+	// start background workers
+	schedulerInterval, err := strconv.Atoi(conf.Worker.SchedulerInterval)
+	handleError(err, "invalid scheduler interval")
+
+	postScheduler := worker.NewPostScheduler(time.Duration(schedulerInterval)*time.Second, rdb, postSvc)
+	runWorker(postScheduler.Start)
+
+	reconcileInterval, err := strconv.Atoi(conf.Worker.ReconcileInterval)
+	handleError(err, "invalid reconcile interval")
+
+	reactionReconciler := worker.NewReactionReconciler(time.Duration(reconcileInterval)*time.Second, rdb, reactionSvc)
+	runWorker(reactionReconciler.Start)
+
+	outboxInterval, err := strconv.Atoi(conf.Worker.OutboxInterval)
+	handleError(err, "invalid outbox interval")
+
+	outboxRelay := worker.NewOutboxRelay(time.Duration(outboxInterval)*time.Second, rdb, outboxSvc)
+	runWorker(outboxRelay.Start)
+
+	webhookInterval, err := strconv.Atoi(conf.Worker.WebhookInterval)
+	handleError(err, "invalid webhook interval")
+
+	webhookDispatcher := worker.NewWebhookDispatcher(time.Duration(webhookInterval)*time.Second, rdb, webhookSvc)
+	runWorker(webhookDispatcher.Start)
+
 	// init router
 	r := handler.NewRouter(
 		conf.HTTP,
@@ -94,9 +282,28 @@ func main() {
 		authHandler,
 		categoryHandler,
 		postHandler,
+		tagHandler,
+		commentHandler,
+		reactionHandler,
+		bookmarkHandler,
+		followHandler,
+		feedHandler,
+		notificationHandler,
+		streamHandler,
+		liveCommentHandler,
+		webhookHandler,
 	)
 
-	// start server
-	err = r.Serve()
+	shutdownTimeout, err := strconv.Atoi(conf.HTTP.ShutdownTimeout)
+	handleError(err, "invalid http shutdown timeout")
+
+	// start server, it returns once a shutdown signal cancels ctx
+	err = r.Serve(ctx, time.Duration(shutdownTimeout)*time.Second)
+
+	// stop the workers and wait for their current run to finish
+	stop()
+	workers.Wait()
 	handleError(err, "failed to run backend server")
+
+	slog.Info("server stopped")
 }
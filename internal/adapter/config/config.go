@@ -3,28 +3,40 @@ package config
 import (
 	"fmt"
 	"os"
+	"runtime/debug"
 
 	"github.com/joho/godotenv"
 )
 
 type (
 	Container struct {
-		App   *App
-		HTTP  *HTTP
-		DB    *DB
-		Redis *Redis
-		JWT   *JWT
+		App     *App
+		HTTP    *HTTP
+		DB      *DB
+		Redis   *Redis
+		JWT     *JWT
+		Worker  *Worker
+		Comment *Comment
+		Feed    *Feed
+		Stream  *Stream
+		Webhook *Webhook
+		Event   *Event
+		Cache   *Cache
 	}
 
 	App struct {
-		Name string
-		Env  string
+		Name    string
+		Env     string
+		Version string
 	}
 
 	HTTP struct {
 		Host           string
 		Port           string
 		AllowedOrigins string
+
+		// ShutdownTimeout is how long in-flight requests get to finish on shutdown
+		ShutdownTimeout string
 	}
 
 	DB struct {
@@ -36,9 +48,38 @@ type (
 	}
 
 	Redis struct {
-		Host     string
-		Port     string
+		// Mode is standalone, sentinel or cluster
+		Mode string
+
+		Host string
+		Port string
+		// Addrs are the comma separated sentinel or cluster seed addresses,
+		// Host and Port are used when empty
+		Addrs string
+		// MasterName is the master monitored by the sentinels
+		MasterName       string
+		SentinelPassword string
+
+		Username string
 		Password string
+		DB       string
+
+		TLS           string
+		TLSCAFile     string
+		TLSServerName string
+		TLSSkipVerify string
+
+		// pool sizes default to go-redis' defaults, timeouts are in milliseconds
+		PoolSize     string
+		MinIdleConns string
+		DialTimeout  string
+		ReadTimeout  string
+		WriteTimeout string
+
+		// the breaker opens after BreakerThreshold failures in a row, redis is
+		// pinged every ProbeInterval seconds until it's back
+		BreakerThreshold string
+		ProbeInterval    string
 	}
 
 	JWT struct {
@@ -47,6 +88,54 @@ type (
 		RefreshTokenDuration string
 		AccessTokenDuration  string
 	}
+
+	Worker struct {
+		SchedulerInterval string
+		ReconcileInterval string
+		WebhookInterval   string
+		OutboxInterval    string
+	}
+
+	Feed struct {
+		FanOutLimit string
+		MaxLength   string
+	}
+
+	Stream struct {
+		HeartbeatInterval string
+		HistorySize       string
+	}
+
+	Cache struct {
+		TTLs        string
+		DefaultTTL  string
+		NegativeTTL string
+		Codec       string
+
+		// Driver is redis, memory or tiered. The memory cache holds at most
+		// MaxEntries keys and MaxBytes of data, tiered keeps keys in memory
+		// for L1TTL seconds in front of redis
+		Driver     string
+		MaxEntries string
+		MaxBytes   string
+		L1TTL      string
+	}
+
+	Event struct {
+		Publisher       string
+		StreamMaxLength string
+	}
+
+	Webhook struct {
+		MaxAttempts string
+		Timeout     string
+	}
+
+	Comment struct {
+		EditWindow     string
+		SpamChecker    string
+		AutoTrustAfter string
+	}
 )
 
 func New() (*Container, error) {
@@ -59,14 +148,17 @@ func New() (*Container, error) {
 	}
 
 	App := &App{
-		Name: os.Getenv("APP_NAME"),
-		Env:  os.Getenv("APP_ENV"),
+		Name:    os.Getenv("APP_NAME"),
+		Env:     os.Getenv("APP_ENV"),
+		Version: appVersion(),
 	}
 
 	HTTP := &HTTP{
 		Host:           os.Getenv("HTTP_HOST"),
 		Port:           os.Getenv("HTTP_PORT"),
 		AllowedOrigins: os.Getenv("HTTP_ALLOWED_ORIGINS"),
+
+		ShutdownTimeout: os.Getenv("HTTP_SHUTDOWN_TIMEOUT"),
 	}
 
 	DB := &DB{
@@ -78,9 +170,31 @@ func New() (*Container, error) {
 	}
 
 	Redis := &Redis{
-		Host:     os.Getenv("REDIS_HOST"),
-		Port:     os.Getenv("REDIS_PORT"),
+		Mode: os.Getenv("REDIS_MODE"),
+
+		Host:             os.Getenv("REDIS_HOST"),
+		Port:             os.Getenv("REDIS_PORT"),
+		Addrs:            os.Getenv("REDIS_ADDRS"),
+		MasterName:       os.Getenv("REDIS_MASTER_NAME"),
+		SentinelPassword: os.Getenv("REDIS_SENTINEL_PASSWORD"),
+
+		Username: os.Getenv("REDIS_USERNAME"),
 		Password: os.Getenv("REDIS_PASSWORD"),
+		DB:       os.Getenv("REDIS_DB"),
+
+		TLS:           os.Getenv("REDIS_TLS"),
+		TLSCAFile:     os.Getenv("REDIS_TLS_CA_FILE"),
+		TLSServerName: os.Getenv("REDIS_TLS_SERVER_NAME"),
+		TLSSkipVerify: os.Getenv("REDIS_TLS_SKIP_VERIFY"),
+
+		PoolSize:     os.Getenv("REDIS_POOL_SIZE"),
+		MinIdleConns: os.Getenv("REDIS_MIN_IDLE_CONNS"),
+		DialTimeout:  os.Getenv("REDIS_DIAL_TIMEOUT"),
+		ReadTimeout:  os.Getenv("REDIS_READ_TIMEOUT"),
+		WriteTimeout: os.Getenv("REDIS_WRITE_TIMEOUT"),
+
+		BreakerThreshold: os.Getenv("REDIS_BREAKER_THRESHOLD"),
+		ProbeInterval:    os.Getenv("REDIS_PROBE_INTERVAL"),
 	}
 
 	JWT := &JWT{
@@ -90,11 +204,79 @@ func New() (*Container, error) {
 		AccessTokenDuration:  os.Getenv("ACCESS_TOKEN_DURATION"),
 	}
 
+	Worker := &Worker{
+		SchedulerInterval: os.Getenv("SCHEDULER_INTERVAL"),
+		ReconcileInterval: os.Getenv("RECONCILE_INTERVAL"),
+		WebhookInterval:   os.Getenv("WEBHOOK_INTERVAL"),
+		OutboxInterval:    os.Getenv("OUTBOX_INTERVAL"),
+	}
+
+	Comment := &Comment{
+		EditWindow:     os.Getenv("COMMENT_EDIT_WINDOW"),
+		SpamChecker:    os.Getenv("COMMENT_SPAM_CHECKER"),
+		AutoTrustAfter: os.Getenv("COMMENT_AUTO_TRUST_AFTER"),
+	}
+
+	Feed := &Feed{
+		FanOutLimit: os.Getenv("FEED_FANOUT_LIMIT"),
+		MaxLength:   os.Getenv("FEED_MAX_LENGTH"),
+	}
+
+	Stream := &Stream{
+		HeartbeatInterval: os.Getenv("STREAM_HEARTBEAT_INTERVAL"),
+		HistorySize:       os.Getenv("STREAM_HISTORY_SIZE"),
+	}
+
+	Webhook := &Webhook{
+		MaxAttempts: os.Getenv("WEBHOOK_MAX_ATTEMPTS"),
+		Timeout:     os.Getenv("WEBHOOK_TIMEOUT"),
+	}
+
+	Event := &Event{
+		Publisher:       os.Getenv("EVENT_PUBLISHER"),
+		StreamMaxLength: os.Getenv("EVENT_STREAM_MAX_LENGTH"),
+	}
+
+	Cache := &Cache{
+		TTLs:        os.Getenv("CACHE_TTLS"),
+		DefaultTTL:  os.Getenv("CACHE_DEFAULT_TTL"),
+		NegativeTTL: os.Getenv("CACHE_NEGATIVE_TTL"),
+		Codec:       os.Getenv("CACHE_CODEC"),
+		Driver:      os.Getenv("CACHE_DRIVER"),
+		MaxEntries:  os.Getenv("CACHE_MAX_ENTRIES"),
+		MaxBytes:    os.Getenv("CACHE_MAX_BYTES"),
+		L1TTL:       os.Getenv("CACHE_L1_TTL"),
+	}
+
 	return &Container{
-		App:   App,
-		HTTP:  HTTP,
-		DB:    DB,
-		Redis: Redis,
-		JWT:   JWT,
+		App:     App,
+		HTTP:    HTTP,
+		DB:      DB,
+		Redis:   Redis,
+		JWT:     JWT,
+		Worker:  Worker,
+		Comment: Comment,
+		Feed:    Feed,
+		Stream:  Stream,
+		Webhook: Webhook,
+		Event:   Event,
+		Cache:   Cache,
 	}, nil
 }
+
+// appVersion defaults to the vcs revision the binary was built from
+func appVersion() string {
+	if version := os.Getenv("APP_VERSION"); version != "" {
+		return version
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" && len(setting.Value) >= 12 {
+				return setting.Value[:12]
+			}
+		}
+	}
+
+	return "dev"
+}
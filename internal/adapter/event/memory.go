@@ -0,0 +1,125 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+// maxHandlerAttempts bounds the deliveries of an event to a failing handler,
+// so one broken event doesn't hold back the outbox or the stream forever
+const maxHandlerAttempts = 5
+
+// MemoryBus delivers events to the handlers in process. Each handler is
+// deduplicated on its own and only marked once it succeeds, a failing handler
+// fails the publisher so the event is redelivered to it, up to
+// maxHandlerAttempts times, while the handlers that succeeded are skipped
+type MemoryBus struct {
+	dedup    port.EventDeduplicator
+	mu       sync.RWMutex
+	handlers []port.EventHandler
+
+	attemptsMu sync.Mutex
+	attempts   map[string]int
+}
+
+func NewMemoryBus(dedup port.EventDeduplicator) *MemoryBus {
+	return &MemoryBus{
+		dedup:    dedup,
+		attempts: map[string]int{},
+	}
+}
+
+func (mb *MemoryBus) Publish(ctx context.Context, events ...domain.Event) error {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	var errs []error
+	for _, event := range events {
+		for i, handler := range mb.handlers {
+			// handlers are subscribed in the same order by every replica
+			key := handlerKey(event, i)
+			if mb.seen(ctx, event, key) {
+				continue
+			}
+
+			if err := handler(ctx, event); err != nil {
+				if mb.retry(event, key) {
+					errs = append(errs, fmt.Errorf("event %s handler %d: %w", event.ID, i, err))
+					continue
+				}
+				slog.Error("event handler failed", "id", event.ID, "type", event.Type, "handler", i, "error", err)
+			}
+
+			mb.markSeen(ctx, event, key)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (mb *MemoryBus) Subscribe(handler port.EventHandler) {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	mb.handlers = append(mb.handlers, handler)
+}
+
+func handlerKey(event domain.Event, handler int) string {
+	return event.ID + ":" + strconv.Itoa(handler)
+}
+
+// retry counts a failed delivery, returning false once the handler ran out of
+// attempts. Without deduplication a redelivery would run every handler again,
+// so failures are only logged
+func (mb *MemoryBus) retry(event domain.Event, key string) bool {
+	if mb.dedup == nil || event.ID == "" {
+		return false
+	}
+
+	mb.attemptsMu.Lock()
+	defer mb.attemptsMu.Unlock()
+
+	mb.attempts[key]++
+	if mb.attempts[key] < maxHandlerAttempts {
+		return true
+	}
+
+	delete(mb.attempts, key)
+	return false
+}
+
+// seen fails open, a redelivered event is better than a lost one
+func (mb *MemoryBus) seen(ctx context.Context, event domain.Event, key string) bool {
+	if mb.dedup == nil || event.ID == "" {
+		return false
+	}
+
+	seen, err := mb.dedup.Seen(ctx, key)
+	if err != nil {
+		slog.Error("unable to deduplicate event", "id", event.ID, "type", event.Type, "error", err)
+		return false
+	}
+
+	return seen
+}
+
+func (mb *MemoryBus) markSeen(ctx context.Context, event domain.Event, key string) {
+	if mb.dedup == nil || event.ID == "" {
+		return
+	}
+
+	mb.attemptsMu.Lock()
+	delete(mb.attempts, key)
+	mb.attemptsMu.Unlock()
+
+	if err := mb.dedup.MarkSeen(ctx, key); err != nil {
+		slog.Error("unable to mark event as handled", "id", event.ID, "type", event.Type, "error", err)
+	}
+}
@@ -0,0 +1,53 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+// memoryDedup remembers the seen keys
+type memoryDedup map[string]bool
+
+func (d memoryDedup) Seen(ctx context.Context, key string) (bool, error) {
+	return d[key], nil
+}
+
+func (d memoryDedup) MarkSeen(ctx context.Context, key string) error {
+	d[key] = true
+	return nil
+}
+
+func TestMemoryBus_Publish(t *testing.T) {
+	ctx := context.Background()
+	event := domain.NewEvent(domain.EventPostCreated, 1, 1)
+
+	bus := NewMemoryBus(memoryDedup{})
+
+	var succeeded, failed int
+	bus.Subscribe(func(ctx context.Context, event domain.Event) error {
+		succeeded++
+		return nil
+	})
+	bus.Subscribe(func(ctx context.Context, event domain.Event) error {
+		failed++
+		return errors.New("unavailable")
+	})
+
+	// the failing handler fails the publisher so the event is redelivered,
+	// the handler that succeeded isn't run again
+	for range maxHandlerAttempts - 1 {
+		assert.Error(t, bus.Publish(ctx, event))
+	}
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, maxHandlerAttempts-1, failed)
+
+	// the failing handler is given up on after its last attempt
+	assert.NoError(t, bus.Publish(ctx, event))
+	assert.NoError(t, bus.Publish(ctx, event))
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, maxHandlerAttempts, failed)
+}
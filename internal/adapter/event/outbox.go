@@ -0,0 +1,30 @@
+package event
+
+import (
+	"context"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+// OutboxBus stores published events in the outbox, in the transaction of ctx if
+// there is one, the relay delivers them to the handlers subscribed to the bus
+type OutboxBus struct {
+	repo port.OutboxRepository
+	bus  port.EventBus
+}
+
+func NewOutboxBus(repo port.OutboxRepository, bus port.EventBus) *OutboxBus {
+	return &OutboxBus{
+		repo,
+		bus,
+	}
+}
+
+func (ob *OutboxBus) Publish(ctx context.Context, events ...domain.Event) error {
+	return ob.repo.SaveEvents(ctx, events...)
+}
+
+func (ob *OutboxBus) Subscribe(handler port.EventHandler) {
+	ob.bus.Subscribe(handler)
+}
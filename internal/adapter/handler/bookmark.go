@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+type BookmarkHandler struct {
+	svc port.BookmarkService
+}
+
+func NewBookmarkHandler(svc port.BookmarkService) *BookmarkHandler {
+	return &BookmarkHandler{
+		svc,
+	}
+}
+
+type AddBookmarkReq struct {
+	PostID uint   `json:"post_id" binding:"required"`
+	Folder string `json:"folder"`
+}
+
+func (bh *BookmarkHandler) AddBookmark(c *gin.Context) {
+	var req AddBookmarkReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	bookmark, err := bh.svc.AddBookmark(c, claims.ID, req.PostID, req.Folder)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, bookmark)
+}
+
+func (bh *BookmarkHandler) RemoveBookmark(c *gin.Context) {
+	postID, err := getIDParam(c, "post_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := bh.svc.RemoveBookmark(c, claims.ID, postID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "bookmark removed successfully",
+	})
+}
+
+func (bh *BookmarkHandler) GetBookmarks(c *gin.Context) {
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	// all folders unless filtered
+	var folder *string
+	if f, ok := c.GetQuery("folder"); ok {
+		folder = &f
+	}
+
+	limit := 0
+	if l := c.Query("limit"); l != "" {
+		limit, err = strconv.Atoi(l)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": domain.ErrInvalidQuery.Error()})
+			return
+		}
+	}
+
+	page, err := bh.svc.GetBookmarks(c, claims.ID, folder, c.Query("cursor"), limit)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (bh *BookmarkHandler) GetBookmarkFolders(c *gin.Context) {
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	folders, err := bh.svc.GetBookmarkFolders(c, claims.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": domain.ErrInternal.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, folders)
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+type CommentHandler struct {
+	svc port.CommentService
+}
+
+func NewCommentHandler(svc port.CommentService) *CommentHandler {
+	return &CommentHandler{
+		svc,
+	}
+}
+
+type CreateCommentReq struct {
+	ParentID *uint  `json:"parent_id"`
+	Content  string `json:"content" binding:"required"`
+}
+
+type EditCommentReq struct {
+	Content string `json:"content" binding:"required"`
+}
+
+func (ch *CommentHandler) CreateComment(c *gin.Context) {
+	postID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req CreateCommentReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := ch.svc.CreateComment(c, &domain.Comment{
+		PostID:   postID,
+		UserID:   claims.ID,
+		ParentID: req.ParentID,
+		Content:  req.Content,
+	})
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+func (ch *CommentHandler) GetComments(c *gin.Context) {
+	postID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// get queries
+	start, end, err := getRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comments, err := ch.svc.GetCommentTree(c, postID, start, end)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": domain.ErrInternal.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+func (ch *CommentHandler) EditComment(c *gin.Context) {
+	id, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req EditCommentReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := ch.svc.EditComment(c, claims.ID, id, req.Content)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+func (ch *CommentHandler) DeleteComment(c *gin.Context) {
+	id, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := ch.svc.DeleteComment(c, claims.ID, claims.Role, id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+type ModerateCommentsReq struct {
+	IDs []uint `json:"ids" binding:"required,min=1"`
+}
+
+type RejectCommentsReq struct {
+	IDs  []uint `json:"ids" binding:"required,min=1"`
+	Spam bool   `json:"spam"`
+}
+
+func (ch *CommentHandler) GetModerationQueue(c *gin.Context) {
+	// pending comments by default
+	status := domain.CommentStatus(c.DefaultQuery("status", string(domain.CommentPending)))
+
+	start, end, err := getRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comments, err := ch.svc.GetModerationQueue(c, status, start, end)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+func (ch *CommentHandler) ApproveComments(c *gin.Context) {
+	var req ModerateCommentsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comments, err := ch.svc.ModerateComments(c, req.IDs, domain.CommentApproved)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+func (ch *CommentHandler) RejectComments(c *gin.Context) {
+	var req RejectCommentsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	status := domain.CommentRejected
+	if req.Spam {
+		status = domain.CommentSpam
+	}
+
+	comments, err := ch.svc.ModerateComments(c, req.IDs, status)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+// maps service errors to their http status code
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrBadRequest), errors.Is(err, domain.ErrInvalidQuery):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrConflictingData):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrForbidden), errors.Is(err, domain.ErrEditWindowExpired):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrPreconditionRequired):
+		return http.StatusPreconditionRequired
+	case errors.Is(err, domain.ErrPreconditionFailed):
+		return http.StatusPreconditionFailed
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+type FeedHandler struct {
+	svc port.FeedService
+}
+
+func NewFeedHandler(svc port.FeedService) *FeedHandler {
+	return &FeedHandler{
+		svc,
+	}
+}
+
+func (fh *FeedHandler) GetFeed(c *gin.Context) {
+	// get queries
+	start, end, err := getRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	posts, err := fh.svc.GetFeed(c, claims.ID, start, end)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": domain.ErrInternal.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, posts)
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+type FollowHandler struct {
+	svc port.FollowService
+}
+
+func NewFollowHandler(svc port.FollowService) *FollowHandler {
+	return &FollowHandler{
+		svc,
+	}
+}
+
+func (fh *FollowHandler) Follow(c *gin.Context) {
+	id, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := fh.svc.Follow(c, claims.ID, id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "user followed successfully",
+	})
+}
+
+func (fh *FollowHandler) Unfollow(c *gin.Context) {
+	id, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := fh.svc.Unfollow(c, claims.ID, id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "user unfollowed successfully",
+	})
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/config"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+const (
+	// time allowed to write a message
+	wsWriteWait = 10 * time.Second
+	// time allowed between pongs, pings are sent more often
+	wsPongWait   = 60 * time.Second
+	wsPingPeriod = wsPongWait * 9 / 10
+	// maximum size of a client message
+	wsMaxMessageSize = 512
+	// minimum time between typing broadcasts of a connection
+	wsTypingInterval = 2 * time.Second
+)
+
+type LiveCommentHandler struct {
+	upgrader websocket.Upgrader
+	jwtConf  *config.JWT
+	svc      port.LiveCommentService
+}
+
+func NewLiveCommentHandler(httpConf *config.HTTP, jwtConf *config.JWT, svc port.LiveCommentService) *LiveCommentHandler {
+	allowedOrigins := strings.Split(httpConf.AllowedOrigins, ",")
+
+	return &LiveCommentHandler{
+		websocket.Upgrader{
+			// only allow browsers on the allowed origins
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				return origin == "" || slices.Contains(allowedOrigins, origin)
+			},
+		},
+		jwtConf,
+		svc,
+	}
+}
+
+type liveCommentClientMsg struct {
+	Type string `json:"type"`
+}
+
+// LiveComments upgrades to a websocket streaming the post's comment changes,
+// clients send {"type":"typing"} while writing a comment
+func (lh *LiveCommentHandler) LiveComments(c *gin.Context) {
+	postID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// browsers can't set headers on websockets, so the token query is allowed
+	claims, err := authenticate(c, lh.jwtConf, true)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	msgs, err := lh.svc.Subscribe(ctx, postID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": domain.ErrInternal.Error()})
+		return
+	}
+
+	conn, err := lh.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// the upgrader already replied with an error
+		return
+	}
+	defer conn.Close()
+
+	go lh.writePump(ctx, cancel, conn, msgs)
+
+	conn.SetReadLimit(wsMaxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	var lastTyping time.Time
+	for {
+		var msg liveCommentClientMsg
+		if err := conn.ReadJSON(&msg); err != nil {
+			return
+		}
+
+		if msg.Type != domain.CommentTyping || time.Since(lastTyping) < wsTypingInterval {
+			continue
+		}
+		lastTyping = time.Now()
+
+		if err := lh.svc.Typing(ctx, postID, claims.ID); err != nil {
+			return
+		}
+	}
+}
+
+// writePump is the only writer of the connection
+func (lh *LiveCommentHandler) writePump(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn, msgs <-chan domain.CommentLiveMessage) {
+	defer cancel()
+
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(wsWriteWait))
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				conn.Close()
+				return
+			}
+		case msg, ok := <-msgs:
+			if !ok {
+				conn.Close()
+				return
+			}
+
+			conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := conn.WriteJSON(msg); err != nil {
+				conn.Close()
+				return
+			}
+		}
+	}
+}
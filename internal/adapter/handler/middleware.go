@@ -12,23 +12,7 @@ import (
 
 func AuthMiddleware(conf *config.JWT) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		tokenString, err := c.Cookie("access_token")
-		if err != nil {
-			// fallback: check Authorization header
-			authHeader := c.GetHeader("Authorization")
-			tokenString, _ = strings.CutPrefix(authHeader, "Bearer ")
-		}
-
-		if tokenString == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": domain.ErrUnauthorized.Error(),
-			})
-			c.Abort()
-			return
-		}
-
-		// parse token
-		claims, err := util.ParseToken(tokenString, conf, "access")
+		claims, err := authenticate(c, conf, false)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": domain.ErrUnauthorized.Error(),
@@ -43,6 +27,33 @@ func AuthMiddleware(conf *config.JWT) gin.HandlerFunc {
 	}
 }
 
+// authenticate parses the access token from the cookie or the Authorization header,
+// allowQuery also accepts the token query for clients that can't set either
+func authenticate(c *gin.Context, conf *config.JWT, allowQuery bool) (*domain.JWTClaims, error) {
+	tokenString, err := c.Cookie("access_token")
+	if err != nil {
+		// fallback: check Authorization header
+		authHeader := c.GetHeader("Authorization")
+		tokenString, _ = strings.CutPrefix(authHeader, "Bearer ")
+	}
+
+	if tokenString == "" && allowQuery {
+		tokenString = c.Query("token")
+	}
+
+	if tokenString == "" {
+		return nil, domain.ErrUnauthorized
+	}
+
+	// parse token
+	claims, err := util.ParseToken(tokenString, conf, "access")
+	if err != nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	return claims, nil
+}
+
 // ensures that the user has at least one of the required roles
 func RoleMiddleware(requiredRoles ...domain.Role) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -83,3 +94,18 @@ func RoleMiddleware(requiredRoles ...domain.Role) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// returns the claims stored by AuthMiddleware
+func getUserClaims(c *gin.Context) (*domain.JWTClaims, error) {
+	user, exists := c.Get("user")
+	if !exists {
+		return nil, domain.ErrUnauthorized
+	}
+
+	claims, ok := user.(*domain.JWTClaims)
+	if !ok {
+		return nil, domain.ErrInternal
+	}
+
+	return claims, nil
+}
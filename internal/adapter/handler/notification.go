@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+type NotificationHandler struct {
+	svc port.NotificationService
+}
+
+func NewNotificationHandler(svc port.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		svc,
+	}
+}
+
+type MarkNotificationsReadReq struct {
+	IDs []uint `json:"ids" binding:"required,min=1"`
+}
+
+func (nh *NotificationHandler) GetNotifications(c *gin.Context) {
+	// get queries
+	start, end, err := getRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	unreadOnly := c.Query("unread") == "true"
+
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	notifications, err := nh.svc.GetNotifications(c, claims.ID, unreadOnly, start, end)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": domain.ErrInternal.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
+
+func (nh *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	count, err := nh.svc.GetUnreadCount(c, claims.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": domain.ErrInternal.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"unread": count,
+	})
+}
+
+func (nh *NotificationHandler) MarkRead(c *gin.Context) {
+	var req MarkNotificationsReadReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := nh.svc.MarkRead(c, claims.ID, req.IDs); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "notifications marked as read",
+	})
+}
+
+func (nh *NotificationHandler) MarkAllRead(c *gin.Context) {
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := nh.svc.MarkAllRead(c, claims.ID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "notifications marked as read",
+	})
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+// mergePatch is a JSON Merge Patch (RFC 7396) document of a flat resource
+type mergePatch map[string]json.RawMessage
+
+func bindMergePatch(c *gin.Context) (mergePatch, error) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, domain.ErrBadRequest
+	}
+
+	// a merge patch must be a json object
+	var patch mergePatch
+	if err := json.Unmarshal(body, &patch); err != nil || patch == nil {
+		return nil, domain.ErrBadRequest
+	}
+
+	return patch, nil
+}
+
+// isNull reports whether the member is explicitly set to null, which removes it
+func (mp mergePatch) isNull(key string) bool {
+	val, ok := mp[key]
+	return ok && bytes.Equal(bytes.TrimSpace(val), []byte("null"))
+}
+
+// patchField decodes a member of the patch. Absent members return nil,
+// null members return a pointer to the zero value.
+func patchField[T any](mp mergePatch, key string) (*T, error) {
+	val, ok := mp[key]
+	if !ok {
+		return nil, nil
+	}
+
+	res := new(T)
+	if mp.isNull(key) {
+		return res, nil
+	}
+
+	if err := json.Unmarshal(val, res); err != nil {
+		return nil, domain.ErrBadRequest
+	}
+
+	return res, nil
+}
+
+// validatePatchField checks a patched value with the binding rules the full
+// replacement is bound with, e.g. "email"
+func validatePatchField[T any](val *T, rules string) error {
+	if val == nil {
+		return nil
+	}
+
+	validate, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return nil
+	}
+
+	if err := validate.Var(*val, rules); err != nil {
+		return domain.ErrBadRequest
+	}
+
+	return nil
+}
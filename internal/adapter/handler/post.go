@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"net/url"
 	"strconv"
-	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
@@ -22,17 +24,21 @@ func NewPostHandler(svc port.PostService) *PostHandler {
 }
 
 type CreatePostReq struct {
-	CategoryID uint   `json:"category_id" binding:"required"`
-	Title      string `json:"title" binding:"required"`
-	Content    string `json:"content" binding:"required"`
-	Published  bool   `json:"published"`
+	CategoryID uint       `json:"category_id" binding:"required"`
+	Title      string     `json:"title" binding:"required"`
+	Content    string     `json:"content" binding:"required"`
+	Published  bool       `json:"published"`
+	PublishAt  *time.Time `json:"publish_at"`
+	Tags       []string   `json:"tags"`
 }
 
 type UpdatePostReq struct {
-	CategoryID uint   `json:"category_id"`
-	Title      string `json:"title"`
-	Content    string `json:"content"`
-	Published  bool   `json:"published"`
+	CategoryID uint       `json:"category_id" binding:"required"`
+	Title      string     `json:"title" binding:"required"`
+	Content    string     `json:"content" binding:"required"`
+	Published  bool       `json:"published"`
+	PublishAt  *time.Time `json:"publish_at"`
+	Tags       []string   `json:"tags"`
 }
 
 func (ph *PostHandler) CreatePost(c *gin.Context) {
@@ -54,18 +60,17 @@ func (ph *PostHandler) CreatePost(c *gin.Context) {
 		return
 	}
 
-	slug := strings.Join(strings.Split(strings.ToLower(req.Title), " "), "-")
-
 	post, err := ph.svc.CreatePost(c, &domain.Post{
 		CategoryID: req.CategoryID,
 		Title:      req.Title,
 		Content:    req.Content,
 		Published:  req.Published,
+		PublishAt:  req.PublishAt,
+		Tags:       newTags(req.Tags),
 		UserID:     claims.ID,
-		Slug:       slug,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -129,6 +134,35 @@ func (ph *PostHandler) GetPostByID(c *gin.Context) {
 		return
 	}
 
+	setETag(c, post.Version)
+	c.JSON(http.StatusOK, post)
+}
+
+func (ph *PostHandler) GetPostBySlug(c *gin.Context) {
+	slug := c.Param("slug")
+
+	post, err := ph.svc.GetPostBySlug(c, slug)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": domain.ErrInternal.Error(),
+		})
+		return
+	}
+
+	// redirect previous slugs to the current one
+	if post.Slug != slug {
+		c.Redirect(http.StatusMovedPermanently, "/api/v1/posts/slug/"+url.PathEscape(post.Slug))
+		return
+	}
+
+	setETag(c, post.Version)
 	c.JSON(http.StatusOK, post)
 }
 
@@ -148,20 +182,104 @@ func (ph *PostHandler) UpdatePost(c *gin.Context) {
 		return
 	}
 
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	ifMatch, err := getIfMatch(c)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
 	var post *domain.Post
 
-	post, err = ph.svc.UpdatePost(c, &domain.Post{
+	post, err = ph.svc.UpdatePost(c, claims.ID, ifMatch, &domain.Post{
 		Model:      gorm.Model{ID: uint(id)},
 		CategoryID: req.CategoryID,
 		Title:      req.Title,
 		Content:    req.Content,
 		Published:  req.Published,
+		PublishAt:  req.PublishAt,
+		Tags:       newTags(req.Tags),
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	setETag(c, post.Version)
+	c.JSON(http.StatusOK, post)
+}
+
+func (ph *PostHandler) PatchPost(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	mp, err := bindMergePatch(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	ifMatch, err := getIfMatch(c)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	// build patch from merge patch members
+	patch := &domain.PostPatch{
+		ClearPublishAt: mp.isNull("publish_at"),
+	}
+	if patch.CategoryID, err = patchField[uint](mp, "category_id"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if patch.Title, err = patchField[string](mp, "title"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if patch.Content, err = patchField[string](mp, "content"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if patch.Published, err = patchField[bool](mp, "published"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if patch.Tags, err = patchField[[]string](mp, "tags"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !patch.ClearPublishAt {
+		if patch.PublishAt, err = patchField[time.Time](mp, "publish_at"); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	post, err := ph.svc.PatchPost(c, claims.ID, uint(id), ifMatch, patch)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
+	setETag(c, post.Version)
 	c.JSON(http.StatusOK, post)
 }
 
@@ -175,13 +293,133 @@ func (ph *PostHandler) DeletePost(c *gin.Context) {
 		return
 	}
 
-	post, err := ph.svc.DeletePost(c, uint(id))
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	post, err := ph.svc.DeletePost(c, claims.ID, uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, post)
+}
+
+func (ph *PostHandler) GetPostRevisions(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": domain.ErrInvalidIDParam.Error(),
+		})
+		return
+	}
+
+	revisions, err := ph.svc.GetPostRevisions(c, uint(id))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": domain.ErrInternal.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+func (ph *PostHandler) GetPostRevisionDiff(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": domain.ErrInvalidIDParam.Error(),
+		})
+		return
+	}
+
+	// get queries
+	from, err := strconv.Atoi(c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": domain.ErrInvalidQuery.Error(),
+		})
+		return
+	}
+
+	to, err := strconv.Atoi(c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": domain.ErrInvalidQuery.Error(),
+		})
+		return
+	}
+
+	diff, err := ph.svc.GetPostRevisionDiff(c, uint(id), uint(from), uint(to))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+func (ph *PostHandler) RestorePostRevision(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": domain.ErrInvalidIDParam.Error(),
+		})
+		return
+	}
+
+	revisionIDStr := c.Param("revision_id")
+	revisionID, err := strconv.Atoi(revisionIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": domain.ErrInvalidIDParam.Error(),
+		})
+		return
+	}
+
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	ifMatch, err := getIfMatch(c)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	post, err := ph.svc.RestorePostRevision(c, claims.ID, uint(id), uint(revisionID), ifMatch)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
 			"error": err.Error(),
 		})
 		return
 	}
 
+	setETag(c, post.Version)
 	c.JSON(http.StatusOK, post)
 }
+
+// builds tags from tag names, the service resolves them
+func newTags(names []string) []domain.Tag {
+	tags := make([]domain.Tag, len(names))
+	for i, name := range names {
+		tags[i] = domain.Tag{Name: name}
+	}
+
+	return tags
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+	"gorm.io/gorm"
+)
+
+// fakePostService returns the same post for every id
+type fakePostService struct {
+	port.PostService
+	post *domain.Post
+}
+
+func (fs fakePostService) GetPostByID(ctx context.Context, id uint) (*domain.Post, error) {
+	return fs.post, nil
+}
+
+func TestPostHandler_GetPostByID_Author(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	post := &domain.Post{
+		Model:   gorm.Model{ID: 1},
+		Title:   "Hello",
+		Content: "World",
+		UserID:  2,
+		User:    domain.Author{ID: 2, Name: "John"},
+		Version: 1,
+	}
+
+	router := gin.New()
+	router.GET("/posts/:id", NewPostHandler(fakePostService{post: post}).GetPostByID)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/posts/1", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res struct {
+		User map[string]any `json:"user"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+
+	// the author only exposes public fields
+	assert.Equal(t, map[string]any{"id": float64(2), "name": "John"}, res.User)
+	assert.NotContains(t, rec.Body.String(), `"email"`)
+	assert.NotContains(t, rec.Body.String(), `"role"`)
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+// sets the ETag header from a resource version
+func setETag(c *gin.Context, version uint) {
+	c.Header("ETag", strconv.Quote(strconv.FormatUint(uint64(version), 10)))
+}
+
+// parses the If-Match header, accepting "*", lists of ETags and strong and weak ETags
+func getIfMatch(c *gin.Context) (domain.IfMatch, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		return domain.IfMatch{}, domain.ErrPreconditionRequired
+	}
+
+	if header == "*" {
+		return domain.IfMatch{Any: true}, nil
+	}
+
+	// tags of other servers never match, our tags hold no commas
+	var ifMatch domain.IfMatch
+	for tag := range strings.SplitSeq(header, ",") {
+		tag, err := strconv.Unquote(strings.TrimPrefix(strings.TrimSpace(tag), "W/"))
+		if err != nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(tag, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		ifMatch.Versions = append(ifMatch.Versions, uint(version))
+	}
+
+	if len(ifMatch.Versions) == 0 {
+		return domain.IfMatch{}, domain.ErrPreconditionFailed
+	}
+
+	return ifMatch, nil
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+func TestGetIfMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		desc     string
+		header   string
+		expected domain.IfMatch
+		err      error
+	}{
+		{desc: "Success", header: `"3"`, expected: domain.MatchVersion(3)},
+		{desc: "Success_Weak", header: `W/"3"`, expected: domain.MatchVersion(3)},
+		{desc: "Success_Any", header: "*", expected: domain.IfMatch{Any: true}},
+		{desc: "Success_List", header: `"2", W/"3" ,"4"`, expected: domain.IfMatch{Versions: []uint{2, 3, 4}}},
+		{desc: "Success_ListWithForeignTag", header: `"abc", "3"`, expected: domain.MatchVersion(3)},
+		{desc: "Fail_Missing", header: "", err: domain.ErrPreconditionRequired},
+		{desc: "Fail_Invalid", header: `"abc", 3`, err: domain.ErrPreconditionFailed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("PUT", "/posts/1", nil)
+			if tc.header != "" {
+				c.Request.Header.Set("If-Match", tc.header)
+			}
+
+			ifMatch, err := getIfMatch(c)
+
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.expected, ifMatch)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+// parses the inclusive start and end pagination queries
+func getRangeQuery(c *gin.Context) (uint64, uint64, error) {
+	start, err := strconv.ParseUint(c.Query("start"), 10, 64)
+	if err != nil {
+		return 0, 0, domain.ErrInvalidQuery
+	}
+
+	end, err := strconv.ParseUint(c.Query("end"), 10, 64)
+	if err != nil || end < start {
+		return 0, 0, domain.ErrInvalidQuery
+	}
+
+	return start, end, nil
+}
+
+// parses a uint path parameter
+func getIDParam(c *gin.Context, name string) (uint, error) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 64)
+	if err != nil {
+		return 0, domain.ErrInvalidIDParam
+	}
+
+	return uint(id), nil
+}
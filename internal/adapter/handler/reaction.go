@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+type ReactionHandler struct {
+	svc port.ReactionService
+}
+
+func NewReactionHandler(svc port.ReactionService) *ReactionHandler {
+	return &ReactionHandler{
+		svc,
+	}
+}
+
+type ToggleReactionReq struct {
+	Type domain.ReactionType `json:"type" binding:"required"`
+}
+
+func (rh *ReactionHandler) ToggleReaction(c *gin.Context) {
+	postID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req ToggleReactionReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	summary, err := rh.svc.ToggleReaction(c, claims.ID, postID, req.Type)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+func (rh *ReactionHandler) GetReactions(c *gin.Context) {
+	postID, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	counts, err := rh.svc.GetPostReactions(c, postID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, counts)
+}
@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"context"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -25,6 +27,16 @@ func NewRouter(
 	authHandler *AuthHandler,
 	categoryHandler *CategoryHandler,
 	postHandler *PostHandler,
+	tagHandler *TagHandler,
+	commentHandler *CommentHandler,
+	reactionHandler *ReactionHandler,
+	bookmarkHandler *BookmarkHandler,
+	followHandler *FollowHandler,
+	feedHandler *FeedHandler,
+	notificationHandler *NotificationHandler,
+	streamHandler *StreamHandler,
+	liveCommentHandler *LiveCommentHandler,
+	webhookHandler *WebhookHandler,
 ) *Router {
 	// init router
 	r := gin.New()
@@ -35,9 +47,9 @@ func NewRouter(
 	// cors config
 	corsConf := cors.New(cors.Config{
 		AllowOrigins:     allowedOrigins,
-		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodDelete, http.MethodPut, http.MethodOptions},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodDelete, http.MethodPut, http.MethodPatch, http.MethodOptions},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "If-Match", "Last-Event-ID"},
+		ExposeHeaders:    []string{"Content-Length", "ETag"},
 		AllowCredentials: true,
 	})
 	r.Use(corsConf)
@@ -59,10 +71,12 @@ func NewRouter(
 	// user user routes
 	us.GET("/users/:id", userHandler.GetUserByID)
 	us.PUT("/users/:id", userHandler.UpdateUser)
+	us.PATCH("/users/:id", userHandler.PatchUser)
 
 	// admin user routes
 	ad.GET("/users", userHandler.GetUsers)
 	ad.DELETE("/users/:id", userHandler.DeleteUser)
+	ad.PUT("/users/:id/trust-level", userHandler.SetTrustLevel)
 
 	// public category routes
 	pb.GET("/categories", categoryHandler.GetCategories)
@@ -70,16 +84,78 @@ func NewRouter(
 
 	// admin category routes
 	ad.POST("/categories", categoryHandler.CreateCategory)
+	ad.PUT("/categories/:id", categoryHandler.UpdateCategory)
+	ad.PATCH("/categories/:id", categoryHandler.PatchCategory)
 	ad.DELETE("/categories/:id", categoryHandler.DeleteCategory)
 
+	// admin webhook routes
+	ad.GET("/webhooks", webhookHandler.GetWebhooks)
+	ad.GET("/webhooks/:id", webhookHandler.GetWebhookByID)
+	ad.POST("/webhooks", webhookHandler.CreateWebhook)
+	ad.PUT("/webhooks/:id", webhookHandler.UpdateWebhook)
+	ad.DELETE("/webhooks/:id", webhookHandler.DeleteWebhook)
+	ad.GET("/webhooks/:id/deliveries", webhookHandler.GetDeliveries)
+	ad.POST("/webhook-deliveries/:id/redeliver", webhookHandler.Redeliver)
+
 	// public post routes
 	pb.GET("/posts", postHandler.GetPosts)
 	pb.GET("/posts/:id", postHandler.GetPostByID)
+	pb.GET("/posts/slug/:slug", postHandler.GetPostBySlug)
+
+	// public comment routes
+	pb.GET("/posts/:id/comments", commentHandler.GetComments)
+
+	// live comment routes, authenticated by the handler
+	pb.GET("/posts/:id/comments/live", liveCommentHandler.LiveComments)
+
+	// user comment routes
+	us.POST("/posts/:id/comments", commentHandler.CreateComment)
+	us.PUT("/comments/:id", commentHandler.EditComment)
+	us.DELETE("/comments/:id", commentHandler.DeleteComment)
+
+	// public reaction routes
+	pb.GET("/posts/:id/reactions", reactionHandler.GetReactions)
+
+	// user reaction routes
+	us.POST("/posts/:id/reactions", reactionHandler.ToggleReaction)
+
+	// user bookmark routes
+	us.GET("/bookmarks", bookmarkHandler.GetBookmarks)
+	us.GET("/bookmarks/folders", bookmarkHandler.GetBookmarkFolders)
+	us.POST("/bookmarks", bookmarkHandler.AddBookmark)
+	us.DELETE("/bookmarks/:post_id", bookmarkHandler.RemoveBookmark)
+
+	// user follow and feed routes
+	us.POST("/users/:id/follow", followHandler.Follow)
+	us.DELETE("/users/:id/follow", followHandler.Unfollow)
+	us.GET("/feed", feedHandler.GetFeed)
+
+	// user notification routes
+	us.GET("/notifications", notificationHandler.GetNotifications)
+	us.GET("/notifications/unread-count", notificationHandler.GetUnreadCount)
+	us.POST("/notifications/read", notificationHandler.MarkRead)
+	us.POST("/notifications/read-all", notificationHandler.MarkAllRead)
+
+	// user real-time stream routes
+	us.GET("/stream", streamHandler.Stream)
+
+	// admin comment moderation routes
+	ad.GET("/moderation/comments", commentHandler.GetModerationQueue)
+	ad.POST("/moderation/comments/approve", commentHandler.ApproveComments)
+	ad.POST("/moderation/comments/reject", commentHandler.RejectComments)
+
+	// public tag routes
+	pb.GET("/tags", tagHandler.GetTags)
+	pb.GET("/tags/:slug/posts", tagHandler.GetPostsByTag)
 
 	// user post routes
 	us.POST("/posts", postHandler.CreatePost)
 	us.PUT("/posts/:id", postHandler.UpdatePost)
+	us.PATCH("/posts/:id", postHandler.PatchPost)
 	us.DELETE("/posts/:id", postHandler.DeletePost)
+	us.GET("/posts/:id/revisions", postHandler.GetPostRevisions)
+	us.GET("/posts/:id/revisions/diff", postHandler.GetPostRevisionDiff)
+	us.POST("/posts/:id/revisions/:revision_id/restore", postHandler.RestorePostRevision)
 
 	return &Router{
 		r,
@@ -88,7 +164,32 @@ func NewRouter(
 	}
 }
 
-func (r *Router) Serve() error {
-	uri := r.httpConf.Host + ":" + r.httpConf.Port
-	return r.r.Run(uri)
+// Serve blocks until ctx is cancelled, then stops accepting connections and
+// gives in-flight requests shutdownTimeout to finish before closing them
+func (r *Router) Serve(ctx context.Context, shutdownTimeout time.Duration) error {
+	srv := &http.Server{
+		Addr:    r.httpConf.Host + ":" + r.httpConf.Port,
+		Handler: r.r,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+	defer cancel()
+
+	// streams never finish by themselves, they are closed once the timeout is up
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return srv.Close()
+	}
+
+	return nil
 }
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+type StreamHandler struct {
+	heartbeat time.Duration
+	svc       port.StreamService
+}
+
+func NewStreamHandler(heartbeat time.Duration, svc port.StreamService) *StreamHandler {
+	return &StreamHandler{
+		heartbeat,
+		svc,
+	}
+}
+
+// Stream sends post and notification events as server-sent events, clients
+// resume with the Last-Event-ID header or the last_event_id query
+func (sh *StreamHandler) Stream(c *gin.Context) {
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	// get last received event id
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	var lastID uint64
+	if lastEventID != "" {
+		lastID, err = strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": domain.ErrInvalidQuery.Error()})
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	msgs, err := sh.svc.Subscribe(ctx, claims.ID, lastID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": domain.ErrInternal.Error()})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(sh.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// comment lines keep proxies from closing idle connections
+			if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case msg, ok := <-msgs:
+			if !ok {
+				return
+			}
+
+			if _, err := fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", msg.ID, msg.Type, msg.Data); err != nil {
+				return
+			}
+		}
+
+		c.Writer.Flush()
+	}
+}
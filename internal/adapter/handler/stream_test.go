@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+// fakeStreamService streams the given messages, the stream stays open unless closed is set
+type fakeStreamService struct {
+	port.StreamService
+	msgs   []domain.StreamMessage
+	closed bool
+	err    error
+	lastID uint64
+}
+
+func (fs *fakeStreamService) Subscribe(ctx context.Context, userID uint, lastID uint64) (<-chan domain.StreamMessage, error) {
+	fs.lastID = lastID
+	if fs.err != nil {
+		return nil, fs.err
+	}
+
+	msgs := make(chan domain.StreamMessage, len(fs.msgs))
+	for _, msg := range fs.msgs {
+		msgs <- msg
+	}
+	if fs.closed {
+		close(msgs)
+	}
+
+	return msgs, nil
+}
+
+func TestStreamHandler_Stream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		desc           string
+		user           bool
+		header         string
+		query          string
+		svc            *fakeStreamService
+		expectedStatus int
+		expectedLastID uint64
+		expectedBody   []string
+	}{
+		{
+			desc: "Success",
+			user: true,
+			svc: &fakeStreamService{
+				msgs: []domain.StreamMessage{
+					{ID: 1, Type: "post.created", Data: []byte(`{"id":1}`)},
+					{ID: 2, Type: "notification", Data: []byte(`{"id":2}`)},
+				},
+				closed: true,
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: []string{
+				"id: 1\nevent: post.created\ndata: {\"id\":1}\n\n",
+				"id: 2\nevent: notification\ndata: {\"id\":2}\n\n",
+			},
+		},
+		{
+			desc:           "Success_LastEventIDHeader",
+			user:           true,
+			header:         "7",
+			query:          "3",
+			svc:            &fakeStreamService{closed: true},
+			expectedStatus: http.StatusOK,
+			expectedLastID: 7,
+		},
+		{
+			desc:           "Success_LastEventIDQuery",
+			user:           true,
+			query:          "3",
+			svc:            &fakeStreamService{closed: true},
+			expectedStatus: http.StatusOK,
+			expectedLastID: 3,
+		},
+		{
+			desc:           "Success_Heartbeat",
+			user:           true,
+			svc:            &fakeStreamService{},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{": heartbeat\n\n"},
+		},
+		{
+			desc:           "Fail_Unauthorized",
+			svc:            &fakeStreamService{},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			desc:           "Fail_InvalidLastEventID",
+			user:           true,
+			header:         "abc",
+			svc:            &fakeStreamService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			desc:           "Fail_Subscribe",
+			user:           true,
+			svc:            &fakeStreamService{err: errors.New("redis unavailable")},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			streamHandler := NewStreamHandler(time.Millisecond, tc.svc)
+
+			router := gin.New()
+			router.GET("/stream", func(c *gin.Context) {
+				if tc.user {
+					c.Set("user", &domain.JWTClaims{ID: 1})
+				}
+			}, streamHandler.Stream)
+
+			// open streams end with the request
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			url := "/stream"
+			if tc.query != "" {
+				url += "?last_event_id=" + tc.query
+			}
+
+			req := httptest.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if tc.header != "" {
+				req.Header.Set("Last-Event-ID", tc.header)
+			}
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+			assert.Equal(t, tc.expectedLastID, tc.svc.lastID)
+			for _, body := range tc.expectedBody {
+				assert.Contains(t, rec.Body.String(), body)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+type TagHandler struct {
+	svc port.TagService
+}
+
+func NewTagHandler(svc port.TagService) *TagHandler {
+	return &TagHandler{
+		svc,
+	}
+}
+
+func (th *TagHandler) GetTags(c *gin.Context) {
+	tags, err := th.svc.GetTags(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": domain.ErrInternal.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+func (th *TagHandler) GetPostsByTag(c *gin.Context) {
+	slug := c.Param("slug")
+
+	// get queries
+	start, end, err := getRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// get posts
+	posts, err := th.svc.GetPostsByTag(c, slug, start, end)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": domain.ErrInternal.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, posts)
+}
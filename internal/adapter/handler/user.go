@@ -42,10 +42,9 @@ func (uh *UserHandler) RegisterUser(c *gin.Context) {
 	}
 
 	_, err := uh.svc.RegisterUser(c, &domain.User{
-		Email:    req.Email,
-		Password: req.Password,
-		Name:     req.Name,
-	})
+		Email: req.Email,
+		Name:  req.Name,
+	}, req.Password)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": domain.ErrInternal.Error(),
@@ -116,13 +115,14 @@ func (uh *UserHandler) GetUserByID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, user)
+	setETag(c, user.Version)
+	c.JSON(http.StatusOK, domain.NewUserResponse(user))
 }
 
 type UpdateUserReq struct {
-	Email    string `json:"email"`
-	Name     string `json:"name"`
-	Password string `json:"password"`
+	Email    string `json:"email" binding:"required,email"`
+	Name     string `json:"name" binding:"required"`
+	Password string `json:"password" binding:"omitempty,min=8"`
 }
 
 func (uh *UserHandler) UpdateUser(c *gin.Context) {
@@ -140,30 +140,117 @@ func (uh *UserHandler) UpdateUser(c *gin.Context) {
 	// get request body
 	var req UpdateUserReq
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": domain.ErrInternal.Error(),
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": domain.ErrBadRequest.Error(),
+		})
+		return
+	}
+
+	// get expected version
+	ifMatch, err := getIfMatch(c)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
 		})
 		return
 	}
 
 	// update user
-	if _, err := uh.svc.UpdateUser(c.Request.Context(), uint(id), &domain.User{
-		Email:    req.Email,
-		Name:     req.Name,
-		Password: req.Password,
-	}); err != nil {
+	user, err := uh.svc.UpdateUser(c.Request.Context(), uint(id), ifMatch, &domain.User{
+		Email: req.Email,
+		Name:  req.Name,
+	}, req.Password)
+	if err != nil {
+		if errors.Is(err, domain.ErrPreconditionFailed) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": domain.ErrInternal.Error(),
 		})
 		return
 	}
 
+	setETag(c, user.Version)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "user updated successfully",
+	})
+}
+
+func (uh *UserHandler) PatchUser(c *gin.Context) {
+	// get id param
+	idStr := c.Param("id")
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": domain.ErrInvalidIDParam.Error(),
+		})
+		return
+	}
+
+	// get merge patch body
+	mp, err := bindMergePatch(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// get expected version
+	ifMatch, err := getIfMatch(c)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// build patch from merge patch members
+	patch := &domain.UserPatch{}
+	if patch.Email, err = patchField[string](mp, "email"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validatePatchField(patch.Email, "required,email"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if patch.Name, err = patchField[string](mp, "name"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if patch.Password, err = patchField[string](mp, "password"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// patch user
+	user, err := uh.svc.PatchUser(c.Request.Context(), uint(id), ifMatch, patch)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	setETag(c, user.Version)
 	c.JSON(http.StatusOK, gin.H{
 		"message": "user updated successfully",
 	})
 }
 
 func (uh *UserHandler) DeleteUser(c *gin.Context) {
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
 	// get id param
 	idStr := c.Param("id")
 
@@ -176,7 +263,7 @@ func (uh *UserHandler) DeleteUser(c *gin.Context) {
 	}
 
 	// delete user
-	if _, err := uh.svc.DeleteUser(c.Request.Context(), uint(id)); err != nil {
+	if _, err := uh.svc.DeleteUser(c.Request.Context(), claims.ID, uint(id)); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": domain.ErrInternal.Error(),
 		})
@@ -187,3 +274,36 @@ func (uh *UserHandler) DeleteUser(c *gin.Context) {
 		"message": "user deleted successfully",
 	})
 }
+
+type SetTrustLevelReq struct {
+	TrustLevel *domain.TrustLevel `json:"trust_level" binding:"required"`
+}
+
+func (uh *UserHandler) SetTrustLevel(c *gin.Context) {
+	id, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req SetTrustLevelReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, err := getUserClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := uh.svc.SetTrustLevel(c.Request.Context(), claims.ID, id, *req.TrustLevel); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "trust level updated successfully",
+	})
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+type WebhookHandler struct {
+	svc port.WebhookService
+}
+
+func NewWebhookHandler(svc port.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		svc,
+	}
+}
+
+type CreateWebhookReq struct {
+	URL        string             `json:"url" binding:"required"`
+	Secret     string             `json:"secret"`
+	EventTypes []domain.EventType `json:"event_types" binding:"required,min=1"`
+	Active     *bool              `json:"active"`
+}
+
+type UpdateWebhookReq struct {
+	URL string `json:"url" binding:"required"`
+	// Secret rotates the signing secret, empty keeps the current one
+	Secret     string             `json:"secret"`
+	EventTypes []domain.EventType `json:"event_types" binding:"required,min=1"`
+	Active     bool               `json:"active"`
+}
+
+func (wh *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req CreateWebhookReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// webhooks are active unless stated otherwise
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	webhook, err := wh.svc.CreateWebhook(c, &domain.Webhook{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+		Active:     active,
+	})
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, domain.CreatedWebhook{Webhook: webhook, Secret: webhook.Secret})
+}
+
+func (wh *WebhookHandler) GetWebhooks(c *gin.Context) {
+	webhooks, err := wh.svc.GetWebhooks(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": domain.ErrInternal.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+func (wh *WebhookHandler) GetWebhookByID(c *gin.Context) {
+	id, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	webhook, err := wh.svc.GetWebhookByID(c, id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+func (wh *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	id, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req UpdateWebhookReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	webhook, err := wh.svc.UpdateWebhook(c, &domain.Webhook{
+		ID:         id,
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+		Active:     req.Active,
+	})
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+func (wh *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	id, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := wh.svc.DeleteWebhook(c, id); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "webhook deleted successfully",
+	})
+}
+
+func (wh *WebhookHandler) GetDeliveries(c *gin.Context) {
+	id, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	start, end, err := getRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	deliveries, err := wh.svc.GetDeliveries(c, id, start, end)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+func (wh *WebhookHandler) Redeliver(c *gin.Context) {
+	id, err := getIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	delivery, err := wh.svc.Redeliver(c, id)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
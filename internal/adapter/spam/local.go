@@ -0,0 +1,149 @@
+package spam
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+var (
+	linkRegex  = regexp.MustCompile(`(?i)(https?://|www\.)\S+`)
+	tokenRegex = regexp.MustCompile(`[\p{L}\p{N}']+`)
+
+	spamKeywords = []string{
+		"buy now", "casino", "click here", "crypto", "earn money", "free money",
+		"limited offer", "loan", "viagra", "work from home",
+	}
+)
+
+// minTrained is the number of trained comments of each class before the classifier is used
+const minTrained = 5
+
+// LocalChecker scores comments with simple heuristics combined with a naive bayes
+// classifier trained from moderation decisions, the training is stored in the
+// repository so it's shared by every replica and survives restarts
+type LocalChecker struct {
+	repo port.SpamModelRepository
+}
+
+func NewLocalChecker(repo port.SpamModelRepository) *LocalChecker {
+	return &LocalChecker{
+		repo,
+	}
+}
+
+func (lc *LocalChecker) Check(ctx context.Context, comment *domain.Comment) (float64, error) {
+	score := heuristicScore(comment.Content)
+	tokens := tokenize(comment.Content)
+	if len(tokens) == 0 {
+		return score, nil
+	}
+
+	// the heuristics alone are used while the model is unavailable
+	model, err := lc.repo.GetSpamModel(ctx, tokens)
+	if err != nil {
+		slog.Warn("unable to load spam model", "error", err)
+		return score, nil
+	}
+
+	// average with the classifier once it has seen enough examples
+	if p, ok := classify(model, tokens); ok {
+		score = (score + p) / 2
+	}
+
+	return score, nil
+}
+
+func (lc *LocalChecker) Train(ctx context.Context, comment *domain.Comment, spam bool) error {
+	return lc.repo.TrainSpamModel(ctx, spam, tokenize(comment.Content))
+}
+
+// classify returns the spam probability of the tokens
+func classify(model *domain.SpamModel, tokens []string) (float64, bool) {
+	if model.SpamCount < minTrained || model.HamCount < minTrained || len(tokens) == 0 {
+		return 0, false
+	}
+
+	// log probabilities with laplace smoothing
+	vocab := float64(model.Vocabulary)
+	docs := float64(model.SpamCount + model.HamCount)
+	spamLog := math.Log(float64(model.SpamCount) / docs)
+	hamLog := math.Log(float64(model.HamCount) / docs)
+
+	for _, token := range tokens {
+		spamLog += math.Log((float64(model.SpamWords[token]) + 1) / (float64(model.SpamTotal) + vocab))
+		hamLog += math.Log((float64(model.HamWords[token]) + 1) / (float64(model.HamTotal) + vocab))
+	}
+
+	return 1 / (1 + math.Exp(hamLog-spamLog)), true
+}
+
+// heuristicScore checks for links, shouting, repeated characters and spam keywords
+func heuristicScore(content string) float64 {
+	score := 0.0
+	lower := strings.ToLower(content)
+
+	// links
+	links := len(linkRegex.FindAllString(content, -1))
+	switch {
+	case links > 2:
+		score += 0.5
+	case links > 0:
+		score += 0.2
+	}
+
+	// mostly uppercase letters
+	var letters, upper int
+	for _, r := range content {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	if letters >= 20 && float64(upper)/float64(letters) > 0.7 {
+		score += 0.2
+	}
+
+	// long runs of the same character
+	if hasRepeatedRun(content, 6) {
+		score += 0.1
+	}
+
+	// spam keywords
+	for _, keyword := range spamKeywords {
+		if strings.Contains(lower, keyword) {
+			score += 0.3
+		}
+	}
+
+	return math.Min(score, 1)
+}
+
+func hasRepeatedRun(content string, n int) bool {
+	var prev rune
+	run := 0
+	for _, r := range content {
+		if r == prev && !unicode.IsSpace(r) {
+			run++
+			if run >= n {
+				return true
+			}
+			continue
+		}
+		prev, run = r, 1
+	}
+
+	return false
+}
+
+func tokenize(content string) []string {
+	return tokenRegex.FindAllString(strings.ToLower(content), -1)
+}
@@ -0,0 +1,98 @@
+package spam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+func TestLocalChecker_Check(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		content string
+		spam    bool
+	}{
+		{
+			name:    "Ham",
+			content: "Great write up, the section on ports cleared things up for me.",
+			spam:    false,
+		},
+		{
+			name:    "Spam",
+			content: "CLICK HERE to EARN MONEY http://a.example http://b.example http://c.example",
+			spam:    true,
+		},
+	}
+
+	checker := NewLocalChecker(newMemorySpamModel())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, err := checker.Check(ctx, &domain.Comment{Content: tt.content})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.spam, score >= 0.9, "score %f", score)
+		})
+	}
+}
+
+func TestLocalChecker_Train(t *testing.T) {
+	ctx := context.Background()
+	checker := NewLocalChecker(newMemorySpamModel())
+
+	for range minTrained {
+		assert.NoError(t, checker.Train(ctx, &domain.Comment{Content: "cheap pills discount pharmacy"}, true))
+		assert.NoError(t, checker.Train(ctx, &domain.Comment{Content: "nice article about go interfaces"}, false))
+	}
+
+	spamScore, err := checker.Check(ctx, &domain.Comment{Content: "discount pills"})
+	assert.NoError(t, err)
+
+	hamScore, err := checker.Check(ctx, &domain.Comment{Content: "go interfaces"})
+	assert.NoError(t, err)
+
+	assert.Greater(t, spamScore, hamScore)
+}
+
+// memorySpamModel keeps the training in memory
+type memorySpamModel struct {
+	model      domain.SpamModel
+	vocabulary map[string]struct{}
+}
+
+func newMemorySpamModel() *memorySpamModel {
+	return &memorySpamModel{
+		model: domain.SpamModel{
+			SpamWords: map[string]int64{},
+			HamWords:  map[string]int64{},
+		},
+		vocabulary: map[string]struct{}{},
+	}
+}
+
+func (m *memorySpamModel) TrainSpamModel(ctx context.Context, spam bool, tokens []string) error {
+	words, total := m.model.HamWords, &m.model.HamTotal
+	if spam {
+		words, total = m.model.SpamWords, &m.model.SpamTotal
+		m.model.SpamCount++
+	} else {
+		m.model.HamCount++
+	}
+
+	for _, token := range tokens {
+		words[token]++
+		*total++
+		m.vocabulary[token] = struct{}{}
+	}
+	m.model.Vocabulary = int64(len(m.vocabulary))
+
+	return nil
+}
+
+func (m *memorySpamModel) GetSpamModel(ctx context.Context, tokens []string) (*domain.SpamModel, error) {
+	model := m.model
+	return &model, nil
+}
@@ -0,0 +1,30 @@
+package spam
+
+import (
+	"context"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/config"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+// New returns the spam checker selected in the config
+func New(conf *config.Comment, repo port.SpamModelRepository) port.SpamChecker {
+	switch conf.SpamChecker {
+	case "none":
+		return &NoopChecker{}
+	default:
+		return NewLocalChecker(repo)
+	}
+}
+
+// NoopChecker treats every comment as ham
+type NoopChecker struct{}
+
+func (nc *NoopChecker) Check(ctx context.Context, comment *domain.Comment) (float64, error) {
+	return 0, nil
+}
+
+func (nc *NoopChecker) Train(ctx context.Context, comment *domain.Comment, spam bool) error {
+	return nil
+}
@@ -0,0 +1,402 @@
+package memory
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNotFound  = errors.New("cache key not found")
+	ErrWrongType = errors.New("cache key holds the wrong kind of value")
+)
+
+type cacheItem struct {
+	key       string
+	val       []byte
+	hash      map[string]string
+	set       map[string]struct{}
+	tags      []string
+	size      int
+	expiresAt time.Time
+}
+
+func (ci *cacheItem) expired(now time.Time) bool {
+	return !ci.expiresAt.IsZero() && now.After(ci.expiresAt)
+}
+
+// Cache is an in-process LRU cache with the same semantics as the redis
+// cache, used in tests, local development and as the first tier in front of
+// redis. Least recently used keys are evicted once maxEntries keys or
+// maxBytes of data are stored, 0 means no limit.
+type Cache struct {
+	maxEntries int
+	maxBytes   int
+
+	mu    sync.Mutex
+	lru   *list.List
+	items map[string]*list.Element
+	tags  map[string]map[string]struct{}
+	bytes int
+}
+
+func NewCache(maxEntries, maxBytes int) *Cache {
+	return &Cache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		lru:        list.New(),
+		items:      map[string]*list.Element{},
+		tags:       map[string]map[string]struct{}{},
+	}
+}
+
+func (c *Cache) Set(ctx context.Context, key string, val []byte, ttl time.Duration) error {
+	return c.SetWithTags(ctx, key, val, ttl)
+}
+
+func (c *Cache) SetWithTags(ctx context.Context, key string, val []byte, ttl time.Duration, tags ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item := &cacheItem{
+		key:  key,
+		val:  append([]byte(nil), val...),
+		tags: slices.Clone(tags),
+	}
+	if ttl > 0 {
+		item.expiresAt = time.Now().Add(ttl)
+	}
+
+	c.store(item)
+	return nil
+}
+
+func (c *Cache) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item := c.lookup(key)
+	if item == nil {
+		return nil, ErrNotFound
+	}
+	if item.val == nil {
+		return nil, ErrWrongType
+	}
+
+	return append([]byte(nil), item.val...), nil
+}
+
+func (c *Cache) InvalidateTags(ctx context.Context, tags ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, tag := range tags {
+		for key := range c.tags[tag] {
+			c.remove(c.items[key])
+		}
+	}
+
+	return nil
+}
+
+func (c *Cache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+
+	return nil
+}
+
+// DeleteByPrefix deletes the keys matching the pattern, * matches any run of
+// characters as in redis' SCAN MATCH
+func (c *Cache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, el := range c.items {
+		if matchPattern(prefix, key) {
+			c.remove(el)
+		}
+	}
+
+	return nil
+}
+
+func (c *Cache) HIncrBy(ctx context.Context, key, field string, incr int64) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item := c.lookup(key)
+	if item == nil {
+		item = &cacheItem{key: key, hash: map[string]string{}}
+	}
+	if item.hash == nil {
+		return 0, ErrWrongType
+	}
+
+	var val int64
+	if cur, ok := item.hash[field]; ok {
+		var err error
+		if val, err = strconv.ParseInt(cur, 10, 64); err != nil {
+			return 0, err
+		}
+	}
+	val += incr
+	item.hash[field] = strconv.FormatInt(val, 10)
+
+	c.store(item)
+	return val, nil
+}
+
+func (c *Cache) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := map[string]string{}
+
+	item := c.lookup(key)
+	if item == nil {
+		return res, nil
+	}
+	if item.hash == nil {
+		return nil, ErrWrongType
+	}
+
+	for field, val := range item.hash {
+		res[field] = val
+	}
+
+	return res, nil
+}
+
+func (c *Cache) HGetAllMulti(ctx context.Context, keys ...string) ([]map[string]string, error) {
+	hashes := make([]map[string]string, len(keys))
+	for i, key := range keys {
+		hash, err := c.HGetAll(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		hashes[i] = hash
+	}
+
+	return hashes, nil
+}
+
+func (c *Cache) HSetNX(ctx context.Context, key string, fields map[string]int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item := c.lookup(key)
+	if item == nil {
+		item = &cacheItem{key: key, hash: map[string]string{}}
+	}
+	if item.hash == nil {
+		return ErrWrongType
+	}
+
+	for field, val := range fields {
+		if _, ok := item.hash[field]; !ok {
+			item.hash[field] = strconv.FormatInt(val, 10)
+		}
+	}
+
+	c.store(item)
+	return nil
+}
+
+func (c *Cache) HReplace(ctx context.Context, key string, fields map[string]int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item := &cacheItem{key: key, hash: map[string]string{}}
+	for field, val := range fields {
+		item.hash[field] = strconv.FormatInt(val, 10)
+	}
+
+	c.store(item)
+	return nil
+}
+
+func (c *Cache) SAdd(ctx context.Context, key string, members ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item := c.lookup(key)
+	if item == nil {
+		item = &cacheItem{key: key, set: map[string]struct{}{}}
+	}
+	if item.set == nil {
+		return ErrWrongType
+	}
+
+	for _, member := range members {
+		item.set[member] = struct{}{}
+	}
+
+	c.store(item)
+	return nil
+}
+
+func (c *Cache) SPop(ctx context.Context, key string, count int64) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item := c.lookup(key)
+	if item == nil {
+		return nil, nil
+	}
+	if item.set == nil {
+		return nil, ErrWrongType
+	}
+
+	var members []string
+	for member := range item.set {
+		if int64(len(members)) >= count {
+			break
+		}
+		members = append(members, member)
+		delete(item.set, member)
+	}
+
+	// redis deletes emptied sets
+	if len(item.set) == 0 {
+		c.remove(c.items[key])
+	} else {
+		c.store(item)
+	}
+
+	return members, nil
+}
+
+// Flush deletes every key
+func (c *Cache) Flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lru.Init()
+	c.items = map[string]*list.Element{}
+	c.tags = map[string]map[string]struct{}{}
+	c.bytes = 0
+}
+
+// Len returns the number of stored keys, expired ones included until they're evicted
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+func (c *Cache) Close() error {
+	return nil
+}
+
+// lookup returns the live item and marks it as recently used, must be called with mu held
+func (c *Cache) lookup(key string) *cacheItem {
+	el, ok := c.items[key]
+	if !ok {
+		return nil
+	}
+
+	item := el.Value.(*cacheItem)
+	if item.expired(time.Now()) {
+		c.remove(el)
+		return nil
+	}
+
+	c.lru.MoveToFront(el)
+	return item
+}
+
+// store adds or replaces the item and evicts until the limits are met, must be called with mu held
+func (c *Cache) store(item *cacheItem) {
+	// hashes and sets are updated in place, the previous size is still recorded
+	if el, ok := c.items[item.key]; ok {
+		c.unlink(el.Value.(*cacheItem))
+		el.Value = item
+		c.lru.MoveToFront(el)
+	} else {
+		c.items[item.key] = c.lru.PushFront(item)
+	}
+	item.size = itemSize(item)
+	c.bytes += item.size
+
+	for _, tag := range item.tags {
+		if c.tags[tag] == nil {
+			c.tags[tag] = map[string]struct{}{}
+		}
+		c.tags[tag][item.key] = struct{}{}
+	}
+
+	for c.lru.Len() > 1 && c.overLimit() {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *Cache) overLimit() bool {
+	return (c.maxEntries > 0 && c.lru.Len() > c.maxEntries) ||
+		(c.maxBytes > 0 && c.bytes > c.maxBytes)
+}
+
+func (c *Cache) remove(el *list.Element) {
+	item := c.lru.Remove(el).(*cacheItem)
+	delete(c.items, item.key)
+	c.unlink(item)
+}
+
+// unlink releases the item's size and drops it from its tags
+func (c *Cache) unlink(item *cacheItem) {
+	c.bytes -= item.size
+
+	for _, tag := range item.tags {
+		delete(c.tags[tag], item.key)
+		if len(c.tags[tag]) == 0 {
+			delete(c.tags, tag)
+		}
+	}
+}
+
+func itemSize(item *cacheItem) int {
+	size := len(item.key) + len(item.val)
+	for field, val := range item.hash {
+		size += len(field) + len(val)
+	}
+	for member := range item.set {
+		size += len(member)
+	}
+
+	return size
+}
+
+// matchPattern reports whether key matches a glob pattern where * matches any
+// run of characters
+func matchPattern(pattern, key string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == key
+	}
+
+	if !strings.HasPrefix(key, parts[0]) {
+		return false
+	}
+	key = key[len(parts[0]):]
+
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(key, part)
+		if i < 0 {
+			return false
+		}
+		key = key[i+len(part):]
+	}
+
+	return strings.HasSuffix(key, last)
+}
@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache(0, 0)
+
+	_, err := c.Get(ctx, "post:1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.NoError(t, c.Set(ctx, "post:1", []byte("a"), 0))
+	assert.NoError(t, c.Set(ctx, "posts:0-10", []byte("b"), 0))
+	assert.NoError(t, c.Set(ctx, "posts:10-20", []byte("c"), 0))
+
+	val, err := c.Get(ctx, "post:1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("a"), val)
+
+	// prefixes are matched like redis patterns
+	assert.NoError(t, c.DeleteByPrefix(ctx, "posts:*"))
+	assert.Equal(t, 1, c.Len())
+
+	assert.NoError(t, c.Delete(ctx, "post:1"))
+	assert.Equal(t, 0, c.Len())
+
+	// hashes and sets
+	count, err := c.HIncrBy(ctx, "reactions:1", "like", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	counts, err := c.HGetAll(ctx, "reactions:1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"like": "2"}, counts)
+
+	_, err = c.Get(ctx, "reactions:1")
+	assert.ErrorIs(t, err, ErrWrongType)
+
+	// loads only set missing fields, replacing resets the hash
+	assert.NoError(t, c.HSetNX(ctx, "reactions:1", map[string]int64{"like": 5, "love": 1}))
+	assert.NoError(t, c.HReplace(ctx, "reactions:2", map[string]int64{"clap": 3}))
+
+	hashes, err := c.HGetAllMulti(ctx, "reactions:1", "reactions:2", "reactions:3")
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{{"like": "2", "love": "1"}, {"clap": "3"}, {}}, hashes)
+
+	assert.NoError(t, c.SAdd(ctx, "dirty", "1", "2"))
+	members, err := c.SPop(ctx, "dirty", 10)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"1", "2"}, members)
+
+	// emptied sets are deleted
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestCache_TTL(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache(0, 0)
+
+	assert.NoError(t, c.Set(ctx, "post:1", []byte("a"), 10*time.Millisecond))
+	time.Sleep(20 * time.Millisecond)
+
+	_, err := c.Get(ctx, "post:1")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCache_Eviction(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		desc       string
+		maxEntries int
+		maxBytes   int
+	}{
+		{
+			desc:       "MaxEntries",
+			maxEntries: 2,
+		},
+		{
+			desc:     "MaxBytes",
+			maxBytes: 12, // two 6 byte entries
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := NewCache(tc.maxEntries, tc.maxBytes)
+
+			assert.NoError(t, c.Set(ctx, "key:1", []byte("a"), 0))
+			assert.NoError(t, c.Set(ctx, "key:2", []byte("b"), 0))
+
+			// key:1 becomes the most recently used
+			_, err := c.Get(ctx, "key:1")
+			assert.NoError(t, err)
+
+			assert.NoError(t, c.Set(ctx, "key:3", []byte("c"), 0))
+			assert.Equal(t, 2, c.Len())
+
+			_, err = c.Get(ctx, "key:2")
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			_, err = c.Get(ctx, "key:1")
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		key      string
+		expected bool
+	}{
+		{"posts:*", "posts:0-10", true},
+		{"posts:*", "post:1", false},
+		{"posts", "posts", true},
+		{"posts", "posts:0-10", false},
+		{"tag:*:posts:*", "tag:go:posts:0-10", true},
+		{"tag:*:posts:*", "tag:go:0-10", false},
+		{"a*a", "a", false},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, matchPattern(tc.pattern, tc.key), tc.pattern+" "+tc.key)
+	}
+}
+
+func TestCache_Tags(t *testing.T) {
+	ctx := context.Background()
+	c := NewCache(0, 0)
+
+	assert.NoError(t, c.SetWithTags(ctx, "post:1", []byte("a"), 0, "post:1"))
+	assert.NoError(t, c.SetWithTags(ctx, "posts:0-10", []byte("b"), 0, "list:posts", "post:1", "post:2"))
+	assert.NoError(t, c.SetWithTags(ctx, "posts:10-20", []byte("c"), 0, "list:posts", "post:3"))
+
+	// the post and the lists containing it
+	assert.NoError(t, c.InvalidateTags(ctx, "post:1"))
+	assert.Equal(t, 1, c.Len())
+
+	_, err := c.Get(ctx, "posts:10-20")
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.InvalidateTags(ctx, "list:posts"))
+	assert.Equal(t, 0, c.Len())
+
+	// evicted keys leave their tags
+	assert.Empty(t, c.tags)
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"sync"
+)
+
+// subscriberBuffer is the number of messages buffered per subscriber, messages
+// to a subscriber with a full buffer are dropped
+const subscriberBuffer = 64
+
+// PubSub delivers messages within the process, used in tests and single instance setups
+type PubSub struct {
+	mu     sync.RWMutex
+	topics map[string]map[chan []byte]struct{}
+}
+
+func NewPubSub() *PubSub {
+	return &PubSub{
+		topics: map[string]map[chan []byte]struct{}{},
+	}
+}
+
+func (ps *PubSub) Publish(ctx context.Context, topic string, payload []byte) error {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	for ch := range ps.topics[topic] {
+		select {
+		case ch <- payload:
+		default:
+		}
+	}
+
+	return nil
+}
+
+func (ps *PubSub) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	ch := make(chan []byte, subscriberBuffer)
+
+	ps.mu.Lock()
+	if ps.topics[topic] == nil {
+		ps.topics[topic] = map[chan []byte]struct{}{}
+	}
+	ps.topics[topic][ch] = struct{}{}
+	ps.mu.Unlock()
+
+	// unsubscribe once ctx is cancelled
+	go func() {
+		<-ctx.Done()
+
+		ps.mu.Lock()
+		defer ps.mu.Unlock()
+
+		delete(ps.topics[topic], ch)
+		if len(ps.topics[topic]) == 0 {
+			delete(ps.topics, topic)
+		}
+		close(ch)
+	}()
+
+	return ch, nil
+}
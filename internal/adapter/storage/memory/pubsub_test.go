@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPubSub(t *testing.T) {
+	ps := NewPubSub()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	msgs, err := ps.Subscribe(ctx, "topic")
+	assert.NoError(t, err)
+
+	other, err := ps.Subscribe(ctx, "other")
+	assert.NoError(t, err)
+
+	assert.NoError(t, ps.Publish(context.Background(), "topic", []byte("hello")))
+
+	select {
+	case msg := <-msgs:
+		assert.Equal(t, []byte("hello"), msg)
+	case <-time.After(time.Second):
+		t.Fatal("message not delivered")
+	}
+
+	select {
+	case <-other:
+		t.Fatal("message delivered to another topic")
+	default:
+	}
+
+	// channels are closed once unsubscribed
+	cancel()
+	for range msgs {
+	}
+	for range other {
+	}
+}
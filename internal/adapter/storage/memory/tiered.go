@@ -0,0 +1,254 @@
+package memory
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+// invalidationTopic is where replicas announce the keys they changed
+const invalidationTopic = "cache:invalidations"
+
+// resubscribeInterval is how long to wait before subscribing again after a failure
+const resubscribeInterval = 5 * time.Second
+
+// readThroughTag tags the keys copied from L2 into L1
+const readThroughTag = "tiered:read-through"
+
+type invalidation struct {
+	Node   string   `json:"node"`
+	Key    string   `json:"key,omitempty"`
+	Prefix bool     `json:"prefix,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+// TieredCache serves hot keys from an in-process L1 in front of a shared L2.
+// Writes go to L2 first and are broadcast so other replicas drop their L1
+// copies. L1 is only read while subscribed to the broadcasts, and keys stay
+// there for at most l1TTL, which bounds staleness from missed messages.
+// Hashes and sets are counters shared by all replicas and always go to L2.
+// Keys copied from L2 don't carry their tags, so any tag invalidation drops
+// all of them from L1.
+type TieredCache struct {
+	l1     *Cache
+	l2     port.CacheRepository
+	pubsub port.PubSub
+	l1TTL  time.Duration
+	node   string
+
+	synced atomic.Bool
+	cancel context.CancelFunc
+}
+
+func NewTieredCache(l1 *Cache, l2 port.CacheRepository, pubsub port.PubSub, l1TTL time.Duration) *TieredCache {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	tc := &TieredCache{
+		l1:     l1,
+		l2:     l2,
+		pubsub: pubsub,
+		l1TTL:  l1TTL,
+		node:   newNodeID(),
+		cancel: cancel,
+	}
+
+	go tc.listen(ctx)
+
+	return tc
+}
+
+func (tc *TieredCache) Set(ctx context.Context, key string, val []byte, ttl time.Duration) error {
+	if err := tc.l2.Set(ctx, key, val, ttl); err != nil {
+		return err
+	}
+
+	tc.l1.Set(ctx, key, val, tc.localTTL(ttl))
+	tc.broadcast(ctx, invalidation{Key: key})
+
+	return nil
+}
+
+func (tc *TieredCache) Get(ctx context.Context, key string) ([]byte, error) {
+	if tc.synced.Load() {
+		if val, err := tc.l1.Get(ctx, key); err == nil {
+			return val, nil
+		}
+	}
+
+	val, err := tc.l2.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// L2 doesn't report the remaining ttl, l1TTL bounds it instead
+	if tc.synced.Load() {
+		tc.l1.SetWithTags(ctx, key, val, tc.l1TTL, readThroughTag)
+	}
+
+	return val, nil
+}
+
+func (tc *TieredCache) SetWithTags(ctx context.Context, key string, val []byte, ttl time.Duration, tags ...string) error {
+	if err := tc.l2.SetWithTags(ctx, key, val, ttl, tags...); err != nil {
+		return err
+	}
+
+	tc.l1.SetWithTags(ctx, key, val, tc.localTTL(ttl), tags...)
+	tc.broadcast(ctx, invalidation{Key: key})
+
+	return nil
+}
+
+func (tc *TieredCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	tc.l1.InvalidateTags(ctx, append(slices.Clip(tags), readThroughTag)...)
+
+	if err := tc.l2.InvalidateTags(ctx, tags...); err != nil {
+		return err
+	}
+
+	tc.broadcast(ctx, invalidation{Tags: tags})
+	return nil
+}
+
+func (tc *TieredCache) Delete(ctx context.Context, key string) error {
+	tc.l1.Delete(ctx, key)
+
+	if err := tc.l2.Delete(ctx, key); err != nil {
+		return err
+	}
+
+	tc.broadcast(ctx, invalidation{Key: key})
+	return nil
+}
+
+func (tc *TieredCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	tc.l1.DeleteByPrefix(ctx, prefix)
+
+	if err := tc.l2.DeleteByPrefix(ctx, prefix); err != nil {
+		return err
+	}
+
+	tc.broadcast(ctx, invalidation{Key: prefix, Prefix: true})
+	return nil
+}
+
+func (tc *TieredCache) HIncrBy(ctx context.Context, key, field string, incr int64) (int64, error) {
+	return tc.l2.HIncrBy(ctx, key, field, incr)
+}
+
+func (tc *TieredCache) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	return tc.l2.HGetAll(ctx, key)
+}
+
+func (tc *TieredCache) HGetAllMulti(ctx context.Context, keys ...string) ([]map[string]string, error) {
+	return tc.l2.HGetAllMulti(ctx, keys...)
+}
+
+func (tc *TieredCache) HSetNX(ctx context.Context, key string, fields map[string]int64) error {
+	return tc.l2.HSetNX(ctx, key, fields)
+}
+
+func (tc *TieredCache) HReplace(ctx context.Context, key string, fields map[string]int64) error {
+	return tc.l2.HReplace(ctx, key, fields)
+}
+
+func (tc *TieredCache) SAdd(ctx context.Context, key string, members ...string) error {
+	return tc.l2.SAdd(ctx, key, members...)
+}
+
+func (tc *TieredCache) SPop(ctx context.Context, key string, count int64) ([]string, error) {
+	return tc.l2.SPop(ctx, key, count)
+}
+
+func (tc *TieredCache) Close() error {
+	tc.cancel()
+	return tc.l2.Close()
+}
+
+func (tc *TieredCache) localTTL(ttl time.Duration) time.Duration {
+	if ttl > 0 && ttl < tc.l1TTL {
+		return ttl
+	}
+
+	return tc.l1TTL
+}
+
+// broadcast is best effort, replicas missing it keep the key for at most l1TTL
+func (tc *TieredCache) broadcast(ctx context.Context, msg invalidation) {
+	msg.Node = tc.node
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		slog.Error("unable to encode cache invalidation", "key", msg.Key, "error", err)
+		return
+	}
+
+	if err := tc.pubsub.Publish(ctx, invalidationTopic, payload); err != nil {
+		slog.Warn("unable to broadcast cache invalidation", "key", msg.Key, "error", err)
+	}
+}
+
+// listen applies other replicas' invalidations to L1, subscribing again
+// whenever the subscription fails or ends
+func (tc *TieredCache) listen(ctx context.Context) {
+	for {
+		msgs, err := tc.pubsub.Subscribe(ctx, invalidationTopic)
+		if err != nil {
+			slog.Warn("unable to subscribe to cache invalidations", "error", err)
+		} else {
+			// invalidations may have been missed while unsubscribed
+			tc.l1.Flush()
+			tc.synced.Store(true)
+
+			for payload := range msgs {
+				tc.apply(ctx, payload)
+			}
+
+			tc.synced.Store(false)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resubscribeInterval):
+		}
+	}
+}
+
+func (tc *TieredCache) apply(ctx context.Context, payload []byte) {
+	var msg invalidation
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		slog.Error("unable to decode cache invalidation", "error", err)
+		return
+	}
+
+	if msg.Node == tc.node {
+		return
+	}
+
+	switch {
+	case msg.Tags != nil:
+		tc.l1.InvalidateTags(ctx, append(msg.Tags, readThroughTag)...)
+	case msg.Prefix:
+		tc.l1.DeleteByPrefix(ctx, msg.Key)
+	default:
+		tc.l1.Delete(ctx, msg.Key)
+	}
+}
+
+func newNodeID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		// crypto/rand doesn't fail on supported platforms
+		panic(err)
+	}
+
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTieredCache(t *testing.T) {
+	ctx := context.Background()
+
+	// two replicas sharing L2 and the broadcasts
+	l2 := NewCache(0, 0)
+	pubsub := NewPubSub()
+
+	a := NewTieredCache(NewCache(0, 0), l2, pubsub, time.Minute)
+	defer a.cancel()
+	b := NewTieredCache(NewCache(0, 0), l2, pubsub, time.Minute)
+	defer b.cancel()
+
+	assert.Eventually(t, func() bool {
+		return a.synced.Load() && b.synced.Load()
+	}, time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, a.Set(ctx, "post:1", []byte("v1"), 0))
+
+	// b loads from L2 into its L1, once a's broadcast of the write has arrived
+	assert.Eventually(t, func() bool {
+		val, err := b.Get(ctx, "post:1")
+		return err == nil && string(val) == "v1" && b.l1.Len() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// a's write drops b's L1 copy
+	assert.NoError(t, a.Set(ctx, "post:1", []byte("v2"), 0))
+	assert.Eventually(t, func() bool {
+		return b.l1.Len() == 0
+	}, time.Second, 10*time.Millisecond)
+
+	val, err := b.Get(ctx, "post:1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v2"), val)
+
+	// and so do prefix deletions
+	assert.NoError(t, a.DeleteByPrefix(ctx, "post:*"))
+	assert.Eventually(t, func() bool {
+		return b.l1.Len() == 0
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = b.Get(ctx, "post:1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// tag invalidations drop tagged keys and those read through from L2
+	assert.NoError(t, a.SetWithTags(ctx, "post:2", []byte("v1"), 0, "post:2"))
+	assert.Eventually(t, func() bool {
+		_, err := b.Get(ctx, "post:2")
+		return err == nil && b.l1.Len() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, a.InvalidateTags(ctx, "post:2"))
+	assert.Equal(t, 0, a.l1.Len())
+	assert.Eventually(t, func() bool {
+		return b.l1.Len() == 0
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = b.Get(ctx, "post:2")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// counters are shared through L2
+	_, err = a.HIncrBy(ctx, "reactions:1", "like", 1)
+	assert.NoError(t, err)
+	count, err := b.HIncrBy(ctx, "reactions:1", "like", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
@@ -15,18 +15,13 @@ type DB struct {
 
 func New(ctx context.Context, conf *config.DB) (*DB, error) {
 	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Jakarta", conf.Host, conf.User, conf.Password, conf.Name, conf.Port)
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		// translate driver errors, e.g. unique violations to gorm.ErrDuplicatedKey
+		TranslateError: true,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	return &DB{db}, nil
 }
-
-func (d *DB) GetDB() *gorm.DB {
-	return d.db
-}
-
-func (d *DB) Migrate(dbs ...any) error {
-	return d.db.AutoMigrate(dbs...)
-}
@@ -0,0 +1,279 @@
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockKey is the advisory lock held while migrating, so replicas
+// starting at once apply each migration once
+const migrationLockKey = 7_140_335_021
+
+// migrationName matches <version>_<name>.<up|down>.sql
+var migrationName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a pair of up and down sql scripts, applied in version order
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus is a migration found in the files or in the database,
+// AppliedAt is nil while it's pending and Unknown is set for migrations
+// applied by another build
+type MigrationStatus struct {
+	Version   uint64
+	Name      string
+	AppliedAt *time.Time
+	Unknown   bool
+}
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version bigint PRIMARY KEY,
+	name varchar(255) NOT NULL,
+	applied_at timestamptz NOT NULL
+)`
+
+// schemaMigration records an applied migration
+type schemaMigration struct {
+	Version   uint64 `gorm:"primaryKey"`
+	Name      string
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// loadMigrations reads the migrations from the sql files, every version needs
+// both an up and a down script
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[uint64]*Migration{}
+	for _, entry := range entries {
+		match := migrationName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, errors.New("invalid migration file name: " + entry.Name())
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, match[2])
+		}
+
+		script, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(script)
+		} else {
+			migration.Down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d needs an up and a down script", migration.Version)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return migrations, nil
+}
+
+// MigrateUp applies the pending migrations, each in its own transaction
+func (d *DB) MigrateUp(ctx context.Context) ([]Migration, error) {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	err = d.withMigrationLock(ctx, func(conn *gorm.DB) error {
+		done, err := appliedMigrations(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Up).Error; err != nil {
+					return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+				}
+
+				return tx.Create(&schemaMigration{
+					Version:   migration.Version,
+					Name:      migration.Name,
+					AppliedAt: time.Now(),
+				}).Error
+			})
+			if err != nil {
+				return err
+			}
+
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// MigrateDown rolls back the last steps applied migrations, newest first
+func (d *DB) MigrateDown(ctx context.Context, steps int) ([]Migration, error) {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	var reverted []Migration
+	err = d.withMigrationLock(ctx, func(conn *gorm.DB) error {
+		var done []schemaMigration
+		if err := conn.Order("version DESC").Limit(steps).Find(&done).Error; err != nil {
+			return err
+		}
+
+		for _, record := range done {
+			i := slices.IndexFunc(migrations, func(m Migration) bool {
+				return m.Version == record.Version
+			})
+			if i < 0 {
+				return fmt.Errorf("migration %d_%s was applied by another build", record.Version, record.Name)
+			}
+			migration := migrations[i]
+
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Down).Error; err != nil {
+					return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+				}
+
+				return tx.Delete(&schemaMigration{}, migration.Version).Error
+			})
+			if err != nil {
+				return err
+			}
+
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// MigrationStatus lists the migrations in version order
+func (d *DB) MigrationStatus(ctx context.Context) ([]MigrationStatus, error) {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []MigrationStatus
+	err = d.withMigrationLock(ctx, func(conn *gorm.DB) error {
+		done, err := appliedMigrations(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range migrations {
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if record, ok := done[migration.Version]; ok {
+				status.AppliedAt = &record.AppliedAt
+				delete(done, migration.Version)
+			}
+			statuses = append(statuses, status)
+		}
+
+		for _, record := range done {
+			statuses = append(statuses, MigrationStatus{
+				Version:   record.Version,
+				Name:      record.Name,
+				AppliedAt: &record.AppliedAt,
+				Unknown:   true,
+			})
+		}
+
+		return nil
+	})
+
+	slices.SortFunc(statuses, func(a, b MigrationStatus) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return statuses, err
+}
+
+// withMigrationLock runs fn on one connection holding the migration lock, the
+// lock is tied to the session so every statement has to use conn
+func (d *DB) withMigrationLock(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	return d.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockKey).Error; err != nil {
+			return err
+		}
+
+		// unlock even if ctx is cancelled, the connection goes back to the pool
+		defer conn.WithContext(context.WithoutCancel(ctx)).Exec("SELECT pg_advisory_unlock(?)", migrationLockKey)
+
+		if err := conn.Exec(createMigrationsTable).Error; err != nil {
+			return err
+		}
+
+		return fn(conn)
+	})
+}
+
+func appliedMigrations(conn *gorm.DB) (map[uint64]schemaMigration, error) {
+	var records []schemaMigration
+	if err := conn.Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	done := make(map[uint64]schemaMigration, len(records))
+	for _, record := range records {
+		done[record.Version] = record
+	}
+
+	return done, nil
+}
@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		files    fstest.MapFS
+		expected []uint64
+		err      bool
+	}{
+		{
+			desc: "Success",
+			files: fstest.MapFS{
+				"migrations/0002_add_bio.up.sql":   {Data: []byte("ALTER TABLE users ADD bio text;")},
+				"migrations/0002_add_bio.down.sql": {Data: []byte("ALTER TABLE users DROP bio;")},
+				"migrations/0001_init.up.sql":      {Data: []byte("CREATE TABLE users ();")},
+				"migrations/0001_init.down.sql":    {Data: []byte("DROP TABLE users;")},
+			},
+			expected: []uint64{1, 2},
+		},
+		{
+			desc: "Fail_MissingDown",
+			files: fstest.MapFS{
+				"migrations/0001_init.up.sql": {Data: []byte("CREATE TABLE users ();")},
+			},
+			err: true,
+		},
+		{
+			desc: "Fail_InvalidName",
+			files: fstest.MapFS{
+				"migrations/init.sql": {Data: []byte("CREATE TABLE users ();")},
+			},
+			err: true,
+		},
+		{
+			desc: "Fail_TwoNames",
+			files: fstest.MapFS{
+				"migrations/0001_init.up.sql":    {Data: []byte("CREATE TABLE users ();")},
+				"migrations/0001_users.down.sql": {Data: []byte("DROP TABLE users;")},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			migrations, err := loadMigrations(tc.files, "migrations")
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			var versions []uint64
+			for _, migration := range migrations {
+				versions = append(versions, migration.Version)
+			}
+			assert.Equal(t, tc.expected, versions)
+		})
+	}
+}
+
+func TestLoadMigrations_Embedded(t *testing.T) {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+	assert.Equal(t, "init", migrations[0].Name)
+}
+
+// createTable matches the create table statements and their column lists
+var createTable = regexp.MustCompile(`(?s)CREATE TABLE (?:IF NOT EXISTS )?(\w+) \((.*?)\n\);`)
+
+func tableColumns(t *testing.T, script string) map[string][]string {
+	t.Helper()
+
+	tables := map[string][]string{}
+	for _, match := range createTable.FindAllStringSubmatch(script, -1) {
+		for _, line := range strings.Split(match[2], "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 0 || fields[0] == "PRIMARY" {
+				continue
+			}
+			tables[match[1]] = append(tables[match[1]], fields[0])
+		}
+	}
+
+	return tables
+}
+
+// TestMigrations_BaselineColumns checks every column the init migration has
+// on top of the AutoMigrate baseline is also added to existing tables
+func TestMigrations_BaselineColumns(t *testing.T) {
+	baseline, err := os.ReadFile("testdata/baseline.sql")
+	assert.NoError(t, err)
+
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	assert.NoError(t, err)
+
+	before := tableColumns(t, string(baseline))
+	after := tableColumns(t, migrations[0].Up)
+	assert.NotEmpty(t, before)
+
+	for table, columns := range before {
+		for _, column := range after[table] {
+			if slices.Contains(columns, column) {
+				continue
+			}
+
+			alter := fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s ", table, column)
+			assert.Contains(t, migrations[0].Up, alter, "%s.%s is never added to existing tables", table, column)
+		}
+	}
+}
+
+// TestMigrateUp_Baseline runs the migrations against a database in the
+// AutoMigrate baseline shape, TEST_DB_DSN is a key/value dsn of a database
+// the test may create schemas in
+func TestMigrateUp_Baseline(t *testing.T) {
+	dsn := os.Getenv("TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DB_DSN is not set")
+	}
+
+	ctx := context.Background()
+	schemaName := fmt.Sprintf("migrate_test_%d", time.Now().UnixNano())
+
+	admin, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, admin.Exec("CREATE SCHEMA "+schemaName).Error)
+	t.Cleanup(func() {
+		admin.Exec("DROP SCHEMA " + schemaName + " CASCADE")
+	})
+
+	db, err := gorm.Open(postgres.Open(dsn+" search_path="+schemaName), &gorm.Config{})
+	assert.NoError(t, err)
+
+	baseline, err := os.ReadFile("testdata/baseline.sql")
+	assert.NoError(t, err)
+	assert.NoError(t, db.Exec(string(baseline)).Error)
+
+	d := &DB{db}
+	applied, err := d.MigrateUp(ctx)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, applied)
+
+	var publishedAt *time.Time
+	assert.NoError(t, db.Raw("SELECT published_at FROM posts WHERE slug = ?", "hello").Scan(&publishedAt).Error)
+	assert.NotNil(t, publishedAt)
+
+	var version, trustLevel int
+	assert.NoError(t, db.Raw("SELECT version, trust_level FROM users WHERE email = ?", "john@example.com").Row().Scan(&version, &trustLevel))
+	assert.Equal(t, 1, version)
+	assert.Equal(t, 0, trustLevel)
+
+	reverted, err := d.MigrateDown(ctx, len(applied))
+	assert.NoError(t, err)
+	assert.Len(t, reverted, len(applied))
+}
@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS outbox_messages;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS follows;
+DROP TABLE IF EXISTS bookmarks;
+DROP TABLE IF EXISTS post_reaction_counts;
+DROP TABLE IF EXISTS reactions;
+DROP TABLE IF EXISTS comments;
+DROP TABLE IF EXISTS post_slugs;
+DROP TABLE IF EXISTS post_revisions;
+DROP TABLE IF EXISTS post_tags;
+DROP TABLE IF EXISTS posts;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS categories;
+DROP TABLE IF EXISTS users;
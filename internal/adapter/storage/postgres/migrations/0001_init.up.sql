@@ -0,0 +1,193 @@
+-- the schema previously created by gorm's AutoMigrate, tables and indexes
+-- are only created when missing so existing databases adopt this baseline.
+-- AutoMigrate added columns to tables that already existed, so those are
+-- added here too for databases migrated by an older build
+
+CREATE TABLE IF NOT EXISTS users (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	updated_at timestamptz,
+	deleted_at timestamptz,
+	email varchar(255) NOT NULL CONSTRAINT uni_users_email UNIQUE,
+	name varchar(255) NOT NULL,
+	role smallint NOT NULL DEFAULT 2001,
+	trust_level smallint NOT NULL DEFAULT 0,
+	version bigint NOT NULL DEFAULT 1,
+	password varchar(255) NOT NULL
+);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS trust_level smallint NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE IF NOT EXISTS categories (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	updated_at timestamptz,
+	deleted_at timestamptz,
+	name varchar(100) NOT NULL CONSTRAINT uni_categories_name UNIQUE,
+	description text
+);
+CREATE INDEX IF NOT EXISTS idx_categories_deleted_at ON categories (deleted_at);
+
+CREATE TABLE IF NOT EXISTS tags (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	updated_at timestamptz,
+	deleted_at timestamptz,
+	name varchar(50) NOT NULL CONSTRAINT uni_tags_name UNIQUE,
+	slug varchar(50) NOT NULL CONSTRAINT uni_tags_slug UNIQUE
+);
+CREATE INDEX IF NOT EXISTS idx_tags_deleted_at ON tags (deleted_at);
+
+CREATE TABLE IF NOT EXISTS posts (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	updated_at timestamptz,
+	deleted_at timestamptz,
+	category_id bigint NOT NULL CONSTRAINT fk_posts_category REFERENCES categories (id),
+	title varchar(255) NOT NULL,
+	content text NOT NULL,
+	published boolean DEFAULT false,
+	publish_at timestamptz,
+	published_at timestamptz,
+	user_id bigint NOT NULL CONSTRAINT fk_posts_user REFERENCES users (id),
+	slug varchar(255) NOT NULL CONSTRAINT uni_posts_slug UNIQUE,
+	version bigint NOT NULL DEFAULT 1
+);
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS publish_at timestamptz;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS published_at timestamptz;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+CREATE INDEX IF NOT EXISTS idx_posts_deleted_at ON posts (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_posts_publish_at ON posts (publish_at);
+CREATE INDEX IF NOT EXISTS idx_posts_published_at ON posts (published_at);
+
+CREATE TABLE IF NOT EXISTS post_tags (
+	post_id bigint CONSTRAINT fk_post_tags_post REFERENCES posts (id),
+	tag_id bigint CONSTRAINT fk_post_tags_tag REFERENCES tags (id),
+	PRIMARY KEY (post_id, tag_id)
+);
+
+CREATE TABLE IF NOT EXISTS post_revisions (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	post_id bigint NOT NULL,
+	user_id bigint NOT NULL,
+	title varchar(255) NOT NULL,
+	content text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_post_revisions_post_id ON post_revisions (post_id);
+
+CREATE TABLE IF NOT EXISTS post_slugs (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	post_id bigint NOT NULL,
+	slug varchar(255) NOT NULL CONSTRAINT uni_post_slugs_slug UNIQUE
+);
+CREATE INDEX IF NOT EXISTS idx_post_slugs_post_id ON post_slugs (post_id);
+
+CREATE TABLE IF NOT EXISTS comments (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	updated_at timestamptz,
+	deleted_at timestamptz,
+	post_id bigint NOT NULL,
+	user_id bigint NOT NULL CONSTRAINT fk_comments_user REFERENCES users (id),
+	parent_id bigint,
+	root_id bigint,
+	content text NOT NULL,
+	status varchar(20) NOT NULL DEFAULT 'approved',
+	spam_score double precision NOT NULL DEFAULT 0
+);
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS status varchar(20) NOT NULL DEFAULT 'approved';
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS spam_score double precision NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_comments_post_id ON comments (post_id);
+CREATE INDEX IF NOT EXISTS idx_comments_parent_id ON comments (parent_id);
+CREATE INDEX IF NOT EXISTS idx_comments_root_id ON comments (root_id);
+CREATE INDEX IF NOT EXISTS idx_comments_status ON comments (status);
+
+CREATE TABLE IF NOT EXISTS reactions (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	updated_at timestamptz,
+	post_id bigint NOT NULL,
+	user_id bigint NOT NULL,
+	type varchar(20) NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reactions_post_user ON reactions (post_id, user_id);
+
+CREATE TABLE IF NOT EXISTS post_reaction_counts (
+	post_id bigint,
+	type varchar(20),
+	count bigint NOT NULL DEFAULT 0,
+	PRIMARY KEY (post_id, type)
+);
+
+CREATE TABLE IF NOT EXISTS bookmarks (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	user_id bigint NOT NULL,
+	post_id bigint NOT NULL CONSTRAINT fk_bookmarks_post REFERENCES posts (id),
+	folder varchar(100) NOT NULL DEFAULT ''
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bookmarks_user_post ON bookmarks (user_id, post_id);
+CREATE INDEX IF NOT EXISTS idx_bookmarks_post_id ON bookmarks (post_id);
+CREATE INDEX IF NOT EXISTS idx_bookmarks_folder ON bookmarks (folder);
+
+CREATE TABLE IF NOT EXISTS follows (
+	created_at timestamptz,
+	follower_id bigint,
+	followee_id bigint,
+	PRIMARY KEY (follower_id, followee_id)
+);
+CREATE INDEX IF NOT EXISTS idx_follows_followee_id ON follows (followee_id);
+
+CREATE TABLE IF NOT EXISTS notifications (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	user_id bigint NOT NULL,
+	type varchar(50) NOT NULL,
+	actor_id bigint,
+	message varchar(255) NOT NULL,
+	post_id bigint,
+	comment_id bigint,
+	read_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_read ON notifications (user_id, read_at);
+
+CREATE TABLE IF NOT EXISTS webhooks (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	updated_at timestamptz,
+	url varchar(2048) NOT NULL,
+	secret varchar(255) NOT NULL,
+	event_types text NOT NULL,
+	active boolean NOT NULL DEFAULT true
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	updated_at timestamptz,
+	webhook_id bigint NOT NULL CONSTRAINT fk_webhook_deliveries_webhook REFERENCES webhooks (id) ON DELETE CASCADE,
+	event_type varchar(50) NOT NULL,
+	payload text NOT NULL,
+	status varchar(20) NOT NULL DEFAULT 'pending',
+	attempts bigint NOT NULL DEFAULT 0,
+	next_attempt_at timestamptz NOT NULL,
+	response_status bigint,
+	response_body text,
+	error text,
+	delivered_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (status, next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS outbox_messages (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	event_id varchar(32) NOT NULL,
+	event_type varchar(50) NOT NULL,
+	payload text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_created_at ON outbox_messages (created_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_messages_event_id ON outbox_messages (event_id);
@@ -0,0 +1,3 @@
+-- the backfilled publish times can't be told apart from recorded ones, so
+-- they are kept
+SELECT 1;
@@ -0,0 +1,5 @@
+-- posts published before published_at was recorded are missing from the feeds,
+-- which only read posts with a publish time
+UPDATE posts
+SET published_at = COALESCE(publish_at, created_at)
+WHERE published AND published_at IS NULL;
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/storage/postgres"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BookmarkRepository struct {
+	db *postgres.DB
+}
+
+func NewBookmarkRepository(db *postgres.DB) *BookmarkRepository {
+	return &BookmarkRepository{
+		db,
+	}
+}
+
+func (br *BookmarkRepository) SaveBookmark(ctx context.Context, bookmark *domain.Bookmark) (*domain.Bookmark, error) {
+	db := br.db.Conn(ctx)
+
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "post_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"folder"}),
+	}, clause.Returning{}).Create(bookmark).Error; err != nil {
+		return nil, err
+	}
+
+	return bookmark, nil
+}
+
+func (br *BookmarkRepository) DeleteBookmark(ctx context.Context, userID, postID uint) error {
+	db := br.db.Conn(ctx)
+
+	res := db.Where("user_id = ? AND post_id = ?", userID, postID).Delete(&domain.Bookmark{})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (br *BookmarkRepository) GetBookmarks(ctx context.Context, userID uint, folder *string, beforeID uint, limit int) ([]domain.Bookmark, error) {
+	db := br.db.Conn(ctx)
+
+	query := visibleBookmarks(db, userID)
+	if folder != nil {
+		query = query.Where("bookmarks.folder = ?", *folder)
+	}
+	if beforeID != 0 {
+		query = query.Where("bookmarks.id < ?", beforeID)
+	}
+
+	var bookmarks []domain.Bookmark
+	if err := query.Order("bookmarks.id DESC").Limit(limit).Preload("Post").Preload("Post.Category").Preload("Post.User").Preload("Post.Tags").Find(&bookmarks).Error; err != nil {
+		return nil, err
+	}
+
+	return bookmarks, nil
+}
+
+func (br *BookmarkRepository) GetBookmarkFolders(ctx context.Context, userID uint) ([]domain.BookmarkFolder, error) {
+	db := br.db.Conn(ctx)
+
+	var folders []domain.BookmarkFolder
+	if err := visibleBookmarks(db.Model(&domain.Bookmark{}), userID).
+		Select("bookmarks.folder AS name, COUNT(*) AS count").
+		Group("bookmarks.folder").
+		Order("bookmarks.folder").
+		Scan(&folders).Error; err != nil {
+		return nil, err
+	}
+
+	return folders, nil
+}
+
+// visibleBookmarks selects the user's bookmarks of posts they can read, posts
+// unpublished after being bookmarked are hidden unless the user wrote them
+func visibleBookmarks(db *gorm.DB, userID uint) *gorm.DB {
+	return db.
+		Joins("JOIN posts ON posts.id = bookmarks.post_id AND posts.deleted_at IS NULL").
+		Where("bookmarks.user_id = ?", userID).
+		Where("posts.published OR posts.user_id = ?", userID)
+}
+
+func (br *BookmarkRepository) DeletePostBookmarks(ctx context.Context, postID uint) error {
+	db := br.db.Conn(ctx)
+
+	return db.Where("post_id = ?", postID).Delete(&domain.Bookmark{}).Error
+}
+
+func (br *BookmarkRepository) DeleteUserBookmarks(ctx context.Context, userID uint) error {
+	db := br.db.Conn(ctx)
+
+	// the posts are soft deleted, so they're still found by author
+	posts := db.Unscoped().Model(&domain.Post{}).Select("id").Where("user_id = ?", userID)
+
+	return db.Where("user_id = ? OR post_id IN (?)", userID, posts).Delete(&domain.Bookmark{}).Error
+}
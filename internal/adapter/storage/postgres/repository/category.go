@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/storage/postgres"
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"gorm.io/gorm"
 )
 
 type CategoryRepository struct {
@@ -18,8 +20,8 @@ func NewCategoryRepository(db *postgres.DB) *CategoryRepository {
 }
 
 func (cr *CategoryRepository) CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
-	db := cr.db.GetDB()
-	if err := db.WithContext(ctx).Create(category).Error; err != nil {
+	db := cr.db.Conn(ctx)
+	if err := db.Create(category).Error; err != nil {
 		return nil, err
 	}
 
@@ -27,10 +29,10 @@ func (cr *CategoryRepository) CreateCategory(ctx context.Context, category *doma
 }
 
 func (cr *CategoryRepository) GetCategories(ctx context.Context) ([]domain.Category, error) {
-	db := cr.db.GetDB()
+	db := cr.db.Conn(ctx)
 
 	var categories []domain.Category
-	if err := db.WithContext(ctx).Find(&categories).Error; err != nil {
+	if err := db.Find(&categories).Error; err != nil {
 		return nil, err
 	}
 
@@ -38,10 +40,22 @@ func (cr *CategoryRepository) GetCategories(ctx context.Context) ([]domain.Categ
 }
 
 func (cr *CategoryRepository) GetCategoryByID(ctx context.Context, id uint) (*domain.Category, error) {
-	db := cr.db.GetDB()
+	db := cr.db.Conn(ctx)
 
 	var category *domain.Category
-	if err := db.WithContext(ctx).Where("id = ?", id).First(&category).Error; err != nil {
+	if err := db.Where("id = ?", id).First(&category).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (cr *CategoryRepository) UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	db := cr.db.Conn(ctx)
+	if err := db.Model(category).Select("*").Omit("created_at").Updates(category).Error; err != nil {
 		return nil, err
 	}
 
@@ -49,10 +63,10 @@ func (cr *CategoryRepository) GetCategoryByID(ctx context.Context, id uint) (*do
 }
 
 func (cr *CategoryRepository) DeleteCategory(ctx context.Context, id uint) (*domain.Category, error) {
-	db := cr.db.GetDB()
+	db := cr.db.Conn(ctx)
 
 	var category *domain.Category
-	if err := db.WithContext(ctx).Where("id = ?", id).Delete(&category).Error; err != nil {
+	if err := db.Where("id = ?", id).Delete(&category).Error; err != nil {
 		return nil, err
 	}
 
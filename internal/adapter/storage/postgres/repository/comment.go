@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/storage/postgres"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CommentRepository struct {
+	db *postgres.DB
+}
+
+func NewCommentRepository(db *postgres.DB) *CommentRepository {
+	return &CommentRepository{
+		db,
+	}
+}
+
+func (cr *CommentRepository) CreateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+	db := cr.db.Conn(ctx)
+	if err := db.Omit(clause.Associations).Create(comment).Error; err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (cr *CommentRepository) GetCommentByID(ctx context.Context, id uint) (*domain.Comment, error) {
+	db := cr.db.Conn(ctx)
+
+	var comment *domain.Comment
+	if err := db.Preload("User").Where("id = ?", id).First(&comment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (cr *CommentRepository) GetRootComments(ctx context.Context, postID uint, start, end uint64) ([]domain.Comment, error) {
+	db := cr.db.Conn(ctx)
+
+	var comments []domain.Comment
+	if err := db.Preload("User").Where("post_id = ? AND parent_id IS NULL AND status = ?", postID, domain.CommentApproved).Order("id").Offset(int(start)).Limit(int(end - start + 1)).Find(&comments).Error; err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (cr *CommentRepository) GetReplies(ctx context.Context, rootIDs []uint) ([]domain.Comment, error) {
+	db := cr.db.Conn(ctx)
+
+	var comments []domain.Comment
+	if len(rootIDs) == 0 {
+		return comments, nil
+	}
+
+	if err := db.Preload("User").Where("root_id IN ? AND status = ?", rootIDs, domain.CommentApproved).Order("id").Find(&comments).Error; err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (cr *CommentRepository) UpdateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+	db := cr.db.Conn(ctx)
+	if err := db.Model(comment).Select("content", "status", "spam_score", "deleted_at", "updated_at").Updates(comment).Error; err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (cr *CommentRepository) GetCommentsByStatus(ctx context.Context, status domain.CommentStatus, start, end uint64) ([]domain.Comment, error) {
+	db := cr.db.Conn(ctx)
+
+	var comments []domain.Comment
+	if err := db.Preload("User").Where("status = ? AND deleted_at IS NULL", status).Order("id").Offset(int(start)).Limit(int(end - start + 1)).Find(&comments).Error; err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (cr *CommentRepository) UpdateCommentsStatus(ctx context.Context, ids []uint, status domain.CommentStatus) ([]domain.Comment, error) {
+	db := cr.db.Conn(ctx)
+
+	var comments []domain.Comment
+	if len(ids) == 0 {
+		return comments, nil
+	}
+
+	if err := db.Model(&comments).Clauses(clause.Returning{}).Where("id IN ? AND status <> ?", ids, status).Update("status", status).Error; err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (cr *CommentRepository) CountUserComments(ctx context.Context, userID uint, status domain.CommentStatus) (int64, error) {
+	db := cr.db.Conn(ctx)
+
+	var count int64
+	if err := db.Model(&domain.Comment{}).Where("user_id = ? AND status = ?", userID, status).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (cr *CommentRepository) DeleteUserComments(ctx context.Context, userID uint) ([]domain.Comment, error) {
+	db := cr.db.Conn(ctx)
+
+	// comments are kept so their replies stay in the thread
+	var comments []domain.Comment
+	if err := db.Model(&comments).Clauses(clause.Returning{}).Where("user_id = ? AND deleted_at IS NULL", userID).Updates(map[string]any{
+		"deleted_at": time.Now(),
+		"content":    "",
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
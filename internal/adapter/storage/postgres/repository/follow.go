@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/storage/postgres"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"gorm.io/gorm"
+)
+
+type FollowRepository struct {
+	db *postgres.DB
+}
+
+func NewFollowRepository(db *postgres.DB) *FollowRepository {
+	return &FollowRepository{
+		db,
+	}
+}
+
+func (fr *FollowRepository) CreateFollow(ctx context.Context, follow *domain.Follow) (*domain.Follow, error) {
+	db := fr.db.Conn(ctx)
+
+	if err := db.Create(follow).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, domain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return follow, nil
+}
+
+func (fr *FollowRepository) DeleteFollow(ctx context.Context, followerID, followeeID uint) error {
+	db := fr.db.Conn(ctx)
+
+	res := db.Where("follower_id = ? AND followee_id = ?", followerID, followeeID).Delete(&domain.Follow{})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (fr *FollowRepository) DeleteUserFollows(ctx context.Context, userID uint) error {
+	db := fr.db.Conn(ctx)
+
+	return db.Where("follower_id = ? OR followee_id = ?", userID, userID).Delete(&domain.Follow{}).Error
+}
+
+func (fr *FollowRepository) GetFollowerIDs(ctx context.Context, userID uint) ([]uint, error) {
+	db := fr.db.Conn(ctx)
+
+	var ids []uint
+	if err := db.Model(&domain.Follow{}).Where("followee_id = ?", userID).Pluck("follower_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (fr *FollowRepository) GetFolloweeIDs(ctx context.Context, userID uint) ([]uint, error) {
+	db := fr.db.Conn(ctx)
+
+	var ids []uint
+	if err := db.Model(&domain.Follow{}).Where("follower_id = ?", userID).Pluck("followee_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (fr *FollowRepository) CountFollows(ctx context.Context, userID uint) (*domain.FollowCounts, error) {
+	db := fr.db.Conn(ctx)
+
+	counts := &domain.FollowCounts{}
+	if err := db.Model(&domain.Follow{}).Where("followee_id = ?", userID).Count(&counts.Followers).Error; err != nil {
+		return nil, err
+	}
+
+	if err := db.Model(&domain.Follow{}).Where("follower_id = ?", userID).Count(&counts.Following).Error; err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (fr *FollowRepository) GetLargeFollowees(ctx context.Context, userID uint, minFollowers int64) ([]uint, error) {
+	db := fr.db.Conn(ctx)
+
+	// only the followers of the user's followees are counted, not the whole table
+	followees := db.Model(&domain.Follow{}).Select("followee_id").Where("follower_id = ?", userID)
+
+	var ids []uint
+	if err := db.Model(&domain.Follow{}).Where("followee_id IN (?)", followees).Group("followee_id").Having("COUNT(*) > ?", minFollowers).Pluck("followee_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/storage/postgres"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+type NotificationRepository struct {
+	db *postgres.DB
+}
+
+func NewNotificationRepository(db *postgres.DB) *NotificationRepository {
+	return &NotificationRepository{
+		db,
+	}
+}
+
+func (nr *NotificationRepository) CreateNotification(ctx context.Context, notification *domain.Notification) (*domain.Notification, error) {
+	db := nr.db.Conn(ctx)
+
+	if err := db.Create(notification).Error; err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+func (nr *NotificationRepository) GetNotifications(ctx context.Context, userID uint, unreadOnly bool, start, end uint64) ([]domain.Notification, error) {
+	db := nr.db.Conn(ctx)
+
+	query := db.Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var notifications []domain.Notification
+	if err := query.Order("id DESC").Offset(int(start)).Limit(int(end - start + 1)).Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (nr *NotificationRepository) MarkRead(ctx context.Context, userID uint, ids []uint) (int64, error) {
+	db := nr.db.Conn(ctx)
+
+	res := db.Model(&domain.Notification{}).Where("user_id = ? AND id IN ? AND read_at IS NULL", userID, ids).Update("read_at", time.Now())
+	if res.Error != nil {
+		return 0, res.Error
+	}
+
+	return res.RowsAffected, nil
+}
+
+func (nr *NotificationRepository) MarkAllRead(ctx context.Context, userID uint) (int64, error) {
+	db := nr.db.Conn(ctx)
+
+	res := db.Model(&domain.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Update("read_at", time.Now())
+	if res.Error != nil {
+		return 0, res.Error
+	}
+
+	return res.RowsAffected, nil
+}
+
+func (nr *NotificationRepository) CountUnread(ctx context.Context, userID uint) (int64, error) {
+	db := nr.db.Conn(ctx)
+
+	var count int64
+	if err := db.Model(&domain.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/storage/postgres"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+type OutboxRepository struct {
+	db *postgres.DB
+}
+
+func NewOutboxRepository(db *postgres.DB) *OutboxRepository {
+	return &OutboxRepository{
+		db,
+	}
+}
+
+// SaveEvents stores the events in the transaction of ctx, if any
+func (or *OutboxRepository) SaveEvents(ctx context.Context, events ...domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	msgs := make([]domain.OutboxMessage, len(events))
+	for i, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		msgs[i] = domain.OutboxMessage{
+			EventID:   event.ID,
+			EventType: event.Type,
+			Payload:   string(payload),
+		}
+	}
+
+	return or.db.Conn(ctx).Create(&msgs).Error
+}
+
+func (or *OutboxRepository) GetOutboxMessages(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+	var msgs []domain.OutboxMessage
+	if err := or.db.Conn(ctx).Order("id").Limit(limit).Find(&msgs).Error; err != nil {
+		return nil, err
+	}
+
+	return msgs, nil
+}
+
+func (or *OutboxRepository) DeleteOutboxMessages(ctx context.Context, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return or.db.Conn(ctx).Delete(&domain.OutboxMessage{}, ids).Error
+}
@@ -2,9 +2,13 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/storage/postgres"
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostRepository struct {
@@ -18,8 +22,15 @@ func NewPostRepository(db *postgres.DB) *PostRepository {
 }
 
 func (pr *PostRepository) CreatePost(ctx context.Context, post *domain.Post) (*domain.Post, error) {
-	db := pr.db.GetDB()
-	if err := db.WithContext(ctx).Create(post).Error; err != nil {
+	db := pr.db.Conn(ctx)
+
+	// insert in a savepoint so a slug conflict doesn't abort the caller's transaction
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		return tx.Create(post).Error
+	}); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, domain.ErrConflictingData
+		}
 		return nil, err
 	}
 
@@ -27,10 +38,10 @@ func (pr *PostRepository) CreatePost(ctx context.Context, post *domain.Post) (*d
 }
 
 func (pr *PostRepository) GetPosts(ctx context.Context, start, end uint64) ([]domain.Post, error) {
-	db := pr.db.GetDB()
+	db := pr.db.Conn(ctx)
 
 	var posts []domain.Post
-	if err := db.WithContext(ctx).Offset(int(start)).Limit(int(end - start + 1)).Preload("Category").Preload("User").Find(&posts).Error; err != nil {
+	if err := db.Offset(int(start)).Limit(int(end - start + 1)).Preload("Category").Preload("User").Preload("Tags").Find(&posts).Error; err != nil {
 		return nil, err
 	}
 
@@ -38,19 +49,44 @@ func (pr *PostRepository) GetPosts(ctx context.Context, start, end uint64) ([]do
 }
 
 func (pr *PostRepository) GetPostByID(ctx context.Context, id uint) (*domain.Post, error) {
-	db := pr.db.GetDB()
+	db := pr.db.Conn(ctx)
 
 	var post *domain.Post
-	if err := db.WithContext(ctx).Preload("Category").Preload("User").Where("id = ?", id).First(&post).Error; err != nil {
+	if err := db.Preload("Category").Preload("User").Preload("Tags").Where("id = ?", id).First(&post).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
 		return nil, err
 	}
 
 	return post, nil
 }
 
+// UpdatePost only updates the post if its stored version still matches post.Version
 func (pr *PostRepository) UpdatePost(ctx context.Context, post *domain.Post) (*domain.Post, error) {
-	db := pr.db.GetDB()
-	if err := db.WithContext(ctx).Save(post).Error; err != nil {
+	db := pr.db.Conn(ctx)
+
+	version := post.Version
+	post.Version++
+
+	// update in a savepoint so a slug conflict doesn't abort the caller's transaction
+	var rows int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(post).Where("version = ?", version).Select("*").Omit("created_at", clause.Associations).Updates(post)
+		rows = res.RowsAffected
+		return res.Error
+	})
+	if err == nil && rows == 0 {
+		err = domain.ErrPreconditionFailed
+	}
+
+	if err != nil {
+		// the post is saved again with the same version on retries
+		post.Version = version
+
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, domain.ErrConflictingData
+		}
 		return nil, err
 	}
 
@@ -58,12 +94,87 @@ func (pr *PostRepository) UpdatePost(ctx context.Context, post *domain.Post) (*d
 }
 
 func (pr *PostRepository) DeletePost(ctx context.Context, id uint) (*domain.Post, error) {
-	db := pr.db.GetDB()
+	db := pr.db.Conn(ctx)
 
 	var post *domain.Post
-	if err := db.WithContext(ctx).Where("id = ?", id).Delete(&post).Error; err != nil {
+	if err := db.Where("id = ?", id).Delete(&post).Error; err != nil {
 		return nil, err
 	}
 
 	return post, nil
 }
+
+func (pr *PostRepository) DeleteUserPosts(ctx context.Context, userID uint) ([]domain.Post, error) {
+	db := pr.db.Conn(ctx)
+
+	var posts []domain.Post
+	if err := db.Clauses(clause.Returning{}).Where("user_id = ?", userID).Delete(&posts).Error; err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+func (pr *PostRepository) PublishDuePosts(ctx context.Context, now time.Time) ([]domain.Post, error) {
+	db := pr.db.Conn(ctx)
+
+	var posts []domain.Post
+	if err := db.Model(&posts).Clauses(clause.Returning{}).Where("published = ? AND publish_at <= ?", false, now).Updates(map[string]any{
+		"published":    true,
+		"published_at": gorm.Expr("publish_at"),
+		"version":      gorm.Expr("version + 1"),
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+func (pr *PostRepository) GetPublishedPostsByIDs(ctx context.Context, ids []uint) ([]domain.Post, error) {
+	db := pr.db.Conn(ctx)
+
+	var posts []domain.Post
+	if len(ids) == 0 {
+		return posts, nil
+	}
+
+	if err := db.Where("id IN ? AND published = ?", ids, true).Preload("Category").Preload("User").Preload("Tags").Find(&posts).Error; err != nil {
+		return nil, err
+	}
+
+	// keep the order of the ids
+	byID := make(map[uint]domain.Post, len(posts))
+	for _, post := range posts {
+		byID[post.ID] = post
+	}
+
+	ordered := make([]domain.Post, 0, len(posts))
+	for _, id := range ids {
+		if post, ok := byID[id]; ok {
+			ordered = append(ordered, post)
+		}
+	}
+
+	return ordered, nil
+}
+
+func (pr *PostRepository) GetFeedEntries(ctx context.Context, authorIDs []uint, limit int) ([]domain.FeedEntry, error) {
+	db := pr.db.Conn(ctx)
+
+	var entries []domain.FeedEntry
+	if len(authorIDs) == 0 {
+		return entries, nil
+	}
+
+	if err := db.
+		Model(&domain.Post{}).
+		Select("id AS post_id, published_at").
+		Where("user_id IN ? AND published = ? AND published_at IS NOT NULL", authorIDs, true).
+		Order("published_at DESC").
+		Limit(limit).
+		Scan(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
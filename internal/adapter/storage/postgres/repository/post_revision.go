@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/storage/postgres"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"gorm.io/gorm"
+)
+
+type PostRevisionRepository struct {
+	db *postgres.DB
+}
+
+func NewPostRevisionRepository(db *postgres.DB) *PostRevisionRepository {
+	return &PostRevisionRepository{
+		db,
+	}
+}
+
+func (rr *PostRevisionRepository) CreateRevision(ctx context.Context, revision *domain.PostRevision) (*domain.PostRevision, error) {
+	db := rr.db.Conn(ctx)
+	if err := db.Create(revision).Error; err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+func (rr *PostRevisionRepository) GetRevisions(ctx context.Context, postID uint) ([]domain.PostRevision, error) {
+	db := rr.db.Conn(ctx)
+
+	var revisions []domain.PostRevision
+	if err := db.Where("post_id = ?", postID).Order("id desc").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (rr *PostRevisionRepository) GetRevisionByID(ctx context.Context, postID, id uint) (*domain.PostRevision, error) {
+	db := rr.db.Conn(ctx)
+
+	var revision domain.PostRevision
+	if err := db.Where("post_id = ? AND id = ?", postID, id).First(&revision).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &revision, nil
+}
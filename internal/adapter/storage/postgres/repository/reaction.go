@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/storage/postgres"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"gorm.io/gorm"
+)
+
+type ReactionRepository struct {
+	db *postgres.DB
+}
+
+func NewReactionRepository(db *postgres.DB) *ReactionRepository {
+	return &ReactionRepository{
+		db,
+	}
+}
+
+func (rr *ReactionRepository) GetReaction(ctx context.Context, postID, userID uint) (*domain.Reaction, error) {
+	db := rr.db.Conn(ctx)
+
+	var reaction domain.Reaction
+	if err := db.Where("post_id = ? AND user_id = ?", postID, userID).First(&reaction).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &reaction, nil
+}
+
+func (rr *ReactionRepository) CreateReaction(ctx context.Context, reaction *domain.Reaction) (*domain.Reaction, error) {
+	db := rr.db.Conn(ctx)
+
+	if err := db.Create(reaction).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, domain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return reaction, nil
+}
+
+func (rr *ReactionRepository) UpdateReaction(ctx context.Context, reaction *domain.Reaction) (*domain.Reaction, error) {
+	db := rr.db.Conn(ctx)
+
+	if err := db.Model(reaction).Select("type", "updated_at").Updates(reaction).Error; err != nil {
+		return nil, err
+	}
+
+	return reaction, nil
+}
+
+func (rr *ReactionRepository) DeleteReaction(ctx context.Context, id uint) error {
+	db := rr.db.Conn(ctx)
+
+	return db.Delete(&domain.Reaction{}, id).Error
+}
+
+func (rr *ReactionRepository) CountReactions(ctx context.Context, postIDs []uint) ([]domain.PostReactionCount, error) {
+	db := rr.db.Conn(ctx)
+
+	var counts []domain.PostReactionCount
+	if err := db.
+		Model(&domain.Reaction{}).
+		Select("post_id, type, COUNT(*) AS count").
+		Where("post_id IN ?", postIDs).
+		Group("post_id, type").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (rr *ReactionRepository) GetReactionCounts(ctx context.Context, postIDs []uint) ([]domain.PostReactionCount, error) {
+	db := rr.db.Conn(ctx)
+
+	var counts []domain.PostReactionCount
+	if err := db.Where("post_id IN ?", postIDs).Find(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (rr *ReactionRepository) SaveReactionCounts(ctx context.Context, postIDs []uint, counts []domain.PostReactionCount) error {
+	db := rr.db.Conn(ctx)
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("post_id IN ?", postIDs).Delete(&domain.PostReactionCount{}).Error; err != nil {
+			return err
+		}
+
+		if len(counts) == 0 {
+			return nil
+		}
+
+		return tx.Create(&counts).Error
+	})
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/storage/postgres"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"gorm.io/gorm"
+)
+
+type SlugRepository struct {
+	db *postgres.DB
+}
+
+func NewSlugRepository(db *postgres.DB) *SlugRepository {
+	return &SlugRepository{
+		db,
+	}
+}
+
+func (sr *SlugRepository) GetTakenSlugs(ctx context.Context, base string, postID uint) ([]string, error) {
+	db := sr.db.Conn(ctx)
+
+	// the unique index also covers soft deleted posts
+	var slugs []string
+	if err := db.Unscoped().Model(&domain.Post{}).Where("slug = ? OR slug LIKE ?", base, base+"-%").Pluck("slug", &slugs).Error; err != nil {
+		return nil, err
+	}
+
+	var redirects []string
+	if err := db.Model(&domain.PostSlug{}).Where("(slug = ? OR slug LIKE ?) AND post_id <> ?", base, base+"-%", postID).Pluck("slug", &redirects).Error; err != nil {
+		return nil, err
+	}
+
+	return append(slugs, redirects...), nil
+}
+
+func (sr *SlugRepository) CreateSlugRedirect(ctx context.Context, redirect *domain.PostSlug) (*domain.PostSlug, error) {
+	db := sr.db.Conn(ctx)
+	if err := db.Create(redirect).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, domain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return redirect, nil
+}
+
+func (sr *SlugRepository) DeleteSlugRedirect(ctx context.Context, postID uint, slug string) error {
+	db := sr.db.Conn(ctx)
+	return db.Where("post_id = ? AND slug = ?", postID, slug).Delete(&domain.PostSlug{}).Error
+}
+
+func (sr *SlugRepository) GetPostIDBySlug(ctx context.Context, slug string) (uint, error) {
+	db := sr.db.Conn(ctx)
+
+	// current slug
+	var post domain.Post
+	err := db.Select("id").Where("slug = ?", slug).First(&post).Error
+	if err == nil {
+		return post.ID, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+
+	// previous slug
+	var redirect domain.PostSlug
+	err = db.Where("slug = ?", slug).First(&redirect).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, domain.ErrNotFound
+		}
+		return 0, err
+	}
+
+	return redirect.PostID, nil
+}
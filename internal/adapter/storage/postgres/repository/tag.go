@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/storage/postgres"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TagRepository struct {
+	db *postgres.DB
+}
+
+func NewTagRepository(db *postgres.DB) *TagRepository {
+	return &TagRepository{
+		db,
+	}
+}
+
+func (tr *TagRepository) GetOrCreateTags(ctx context.Context, tags []domain.Tag) ([]domain.Tag, error) {
+	db := tr.db.Conn(ctx)
+
+	if len(tags) == 0 {
+		return []domain.Tag{}, nil
+	}
+
+	// create missing tags, existing ones are left untouched
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	slugs := make([]string, len(tags))
+	for i, tag := range tags {
+		slugs[i] = tag.Slug
+	}
+
+	var res []domain.Tag
+	if err := db.Where("slug IN ?", slugs).Find(&res).Error; err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (tr *TagRepository) GetTagsWithCount(ctx context.Context) ([]domain.TagWithCount, error) {
+	db := tr.db.Conn(ctx)
+
+	var tags []domain.TagWithCount
+	if err := db.
+		Model(&domain.Tag{}).
+		Select("tags.*, COUNT(posts.id) AS post_count").
+		Joins("LEFT JOIN post_tags ON post_tags.tag_id = tags.id").
+		Joins("LEFT JOIN posts ON posts.id = post_tags.post_id AND posts.deleted_at IS NULL").
+		Group("tags.id").
+		Order("post_count DESC, tags.name").
+		Scan(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (tr *TagRepository) GetTagBySlug(ctx context.Context, slug string) (*domain.Tag, error) {
+	db := tr.db.Conn(ctx)
+
+	var tag *domain.Tag
+	if err := db.Where("slug = ?", slug).First(&tag).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (tr *TagRepository) GetPostsByTag(ctx context.Context, tagID uint, start, end uint64) ([]domain.Post, error) {
+	db := tr.db.Conn(ctx)
+
+	var posts []domain.Post
+	if err := db.
+		Joins("JOIN post_tags ON post_tags.post_id = posts.id AND post_tags.tag_id = ?", tagID).
+		Offset(int(start)).Limit(int(end - start + 1)).
+		Preload("Category").Preload("User").Preload("Tags").
+		Find(&posts).Error; err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+func (tr *TagRepository) ReplacePostTags(ctx context.Context, post *domain.Post, tags []domain.Tag) error {
+	db := tr.db.Conn(ctx)
+	return db.Model(post).Association("Tags").Replace(tags)
+}
@@ -2,11 +2,25 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/storage/postgres"
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/util"
+	"gorm.io/gorm"
 )
 
+// userRecord is the stored user, the password hash stays in this package
+type userRecord struct {
+	domain.User
+
+	Password string `gorm:"size:255;not null"`
+}
+
+func (userRecord) TableName() string {
+	return "users"
+}
+
 type UserRepository struct {
 	db *postgres.DB
 }
@@ -17,25 +31,32 @@ func NewUserRepository(db *postgres.DB) *UserRepository {
 	}
 }
 
-func (ur *UserRepository) CreateUser(ctx context.Context, user *domain.User) (*domain.UserResponse, error) {
-	db := ur.db.GetDB()
+func (ur *UserRepository) CreateUser(ctx context.Context, user *domain.User, password string) (*domain.UserResponse, error) {
+	db := ur.db.Conn(ctx)
+
+	hashed, err := util.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := db.WithContext(ctx).Create(user).Error; err != nil {
+	record := &userRecord{*user, hashed}
+	if err := db.Create(record).Error; err != nil {
 		return nil, domain.ErrInternal
 	}
 
-	return &domain.UserResponse{
-		Name:  user.Name,
-		Email: user.Email,
-		Role:  user.Role,
-	}, nil
+	// the id and defaults are assigned on insert
+	*user = record.User
+	return domain.NewUserResponse(user), nil
 }
 
 func (ur *UserRepository) GetUserByID(ctx context.Context, id uint) (*domain.User, error) {
-	db := ur.db.GetDB()
+	db := ur.db.Conn(ctx)
 
 	var user *domain.User
-	if err := db.WithContext(ctx).First(&user, id).Error; err != nil {
+	if err := db.First(&user, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
 		return nil, domain.ErrInternal
 	}
 
@@ -43,18 +64,36 @@ func (ur *UserRepository) GetUserByID(ctx context.Context, id uint) (*domain.Use
 }
 
 func (ur *UserRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
-	db := ur.db.GetDB()
+	db := ur.db.Conn(ctx)
 
 	var user *domain.User
-	if err := db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
+	if err := db.Where("email = ?", email).First(&user).Error; err != nil {
 		return nil, domain.ErrInternal
 	}
 
 	return user, nil
 }
 
+func (ur *UserRepository) VerifyPassword(ctx context.Context, email, password string) (*domain.User, error) {
+	db := ur.db.Conn(ctx)
+
+	var record userRecord
+	if err := db.Where("email = ?", email).First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, domain.ErrInternal
+	}
+
+	if err := util.CompareHashedPwd(record.Password, password); err != nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	return &record.User, nil
+}
+
 func (ur *UserRepository) GetUsers(ctx context.Context, start, stop uint64) ([]domain.UserResponse, error) {
-	db := ur.db.GetDB()
+	db := ur.db.Conn(ctx)
 
 	var users []domain.UserResponse
 	if err := db.Model(&domain.User{}).Offset(int(start)).Limit(int(stop - start + 1)).Find(&users).Error; err != nil {
@@ -64,21 +103,57 @@ func (ur *UserRepository) GetUsers(ctx context.Context, start, stop uint64) ([]d
 	return users, nil
 }
 
-func (ur *UserRepository) UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
-	db := ur.db.GetDB()
+// UpdateUser only updates the user if its stored version still matches user.Version
+func (ur *UserRepository) UpdateUser(ctx context.Context, user *domain.User, password string) (*domain.User, error) {
+	db := ur.db.Conn(ctx)
+
+	record := &userRecord{User: *user}
+	record.Version++
+
+	omit := []string{"created_at"}
+	if password == "" {
+		omit = append(omit, "password")
+	} else {
+		hashed, err := util.HashPassword(password)
+		if err != nil {
+			return nil, err
+		}
+		record.Password = hashed
+	}
 
-	if err := db.WithContext(ctx).Save(user).Error; err != nil {
+	res := db.Model(record).Where("version = ?", user.Version).Select("*").Omit(omit...).Updates(record)
+	if res.Error != nil {
 		return nil, domain.ErrInternal
 	}
 
+	if res.RowsAffected == 0 {
+		return nil, domain.ErrPreconditionFailed
+	}
+
+	*user = record.User
 	return user, nil
 }
 
+func (ur *UserRepository) UpdateTrustLevel(ctx context.Context, id uint, level domain.TrustLevel) error {
+	db := ur.db.Conn(ctx)
+
+	// the trust level is part of the user, so its version changes with it
+	err := db.Model(&domain.User{}).Where("id = ?", id).Updates(map[string]any{
+		"trust_level": level,
+		"version":     gorm.Expr("version + 1"),
+	}).Error
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
 func (ur *UserRepository) DeleteUser(ctx context.Context, id uint) (*domain.User, error) {
-	db := ur.db.GetDB()
+	db := ur.db.Conn(ctx)
 
 	var user *domain.User
-	if err := db.WithContext(ctx).Where("id = ?", id).Delete(&user).Error; err != nil {
+	if err := db.Where("id = ?", id).Delete(&user).Error; err != nil {
 		return nil, domain.ErrInternal
 	}
 
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"gorm.io/gorm/schema"
+)
+
+func TestUserRecord_Schema(t *testing.T) {
+	cache := &sync.Map{}
+
+	record, err := schema.Parse(&userRecord{}, cache, schema.NamingStrategy{})
+	assert.NoError(t, err)
+	assert.Equal(t, "users", record.Table)
+	assert.NotNil(t, record.LookUpField("password"))
+
+	// users loaded outside the record, e.g. preloaded with posts, never map the hash
+	user, err := schema.Parse(&domain.User{}, cache, schema.NamingStrategy{})
+	assert.NoError(t, err)
+	assert.Equal(t, "users", user.Table)
+	assert.Nil(t, user.LookUpField("password"))
+}
+
+func TestAuthor_Schema(t *testing.T) {
+	cache := &sync.Map{}
+
+	// authors preloaded with posts and comments only map public user fields
+	for _, model := range []any{&domain.Post{}, &domain.Comment{}} {
+		parsed, err := schema.Parse(model, cache, schema.NamingStrategy{})
+		assert.NoError(t, err)
+
+		author := parsed.Relationships.Relations["User"]
+		assert.NotNil(t, author)
+		assert.Equal(t, "users", author.FieldSchema.Table)
+		assert.ElementsMatch(t, []string{"id", "name"}, author.FieldSchema.DBNames)
+	}
+}
+
+// TestRepositories_NoPasswordHash walks every value returned by the
+// repositories, none may hold the stored password hash
+func TestRepositories_NoPasswordHash(t *testing.T) {
+	repos := []any{
+		&BookmarkRepository{},
+		&CategoryRepository{},
+		&CommentRepository{},
+		&FollowRepository{},
+		&NotificationRepository{},
+		&OutboxRepository{},
+		&PostRepository{},
+		&PostRevisionRepository{},
+		&ReactionRepository{},
+		&SlugRepository{},
+		&TagRepository{},
+		&UserRepository{},
+		&WebhookRepository{},
+	}
+
+	seen := map[reflect.Type]bool{}
+	for _, repo := range repos {
+		typ := reflect.TypeOf(repo)
+		for i := range typ.NumMethod() {
+			method := typ.Method(i)
+			for j := range method.Type.NumOut() {
+				path := typ.Elem().Name() + "." + method.Name
+				assertNoPasswordHash(t, path, method.Type.Out(j), seen)
+			}
+		}
+	}
+}
+
+func assertNoPasswordHash(t *testing.T, path string, typ reflect.Type, seen map[reflect.Type]bool) {
+	if seen[typ] {
+		return
+	}
+	seen[typ] = true
+
+	switch typ.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Chan:
+		assertNoPasswordHash(t, path, typ.Elem(), seen)
+	case reflect.Map:
+		assertNoPasswordHash(t, path, typ.Key(), seen)
+		assertNoPasswordHash(t, path, typ.Elem(), seen)
+	case reflect.Struct:
+		assert.NotEqual(t, reflect.TypeOf(userRecord{}), typ, path)
+
+		for i := range typ.NumField() {
+			field := typ.Field(i)
+			assert.NotEqual(t, "Password", field.Name, path+" returns "+typ.String())
+			assertNoPasswordHash(t, path, field.Type, seen)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/storage/postgres"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository struct {
+	db *postgres.DB
+}
+
+func NewWebhookRepository(db *postgres.DB) *WebhookRepository {
+	return &WebhookRepository{
+		db,
+	}
+}
+
+func (wr *WebhookRepository) CreateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	db := wr.db.Conn(ctx)
+
+	if err := db.Create(webhook).Error; err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (wr *WebhookRepository) GetWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	db := wr.db.Conn(ctx)
+
+	var webhooks []domain.Webhook
+	if err := db.Order("id").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (wr *WebhookRepository) GetActiveWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	db := wr.db.Conn(ctx)
+
+	var webhooks []domain.Webhook
+	if err := db.Where("active = ?", true).Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (wr *WebhookRepository) GetWebhookByID(ctx context.Context, id uint) (*domain.Webhook, error) {
+	db := wr.db.Conn(ctx)
+
+	var webhook domain.Webhook
+	if err := db.First(&webhook, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (wr *WebhookRepository) UpdateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	db := wr.db.Conn(ctx)
+
+	if err := db.Model(webhook).Select("url", "secret", "event_types", "active", "updated_at").Updates(webhook).Error; err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (wr *WebhookRepository) DeleteWebhook(ctx context.Context, id uint) error {
+	db := wr.db.Conn(ctx)
+
+	res := db.Delete(&domain.Webhook{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (wr *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error {
+	db := wr.db.Conn(ctx)
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return db.Create(&deliveries).Error
+}
+
+func (wr *WebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	db := wr.db.Conn(ctx)
+
+	// rows claimed by a concurrent claim are skipped instead of waited for
+	due := db.
+		Model(&domain.WebhookDelivery{}).
+		Select("id").
+		Where("status = ? AND next_attempt_at <= ?", domain.DeliveryPending, now).
+		Order("next_attempt_at").
+		Limit(limit).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked})
+
+	var claimed []domain.WebhookDelivery
+	if err := db.Model(&claimed).Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).Where("id IN (?)", due).Update("next_attempt_at", now.Add(lease)).Error; err != nil {
+		return nil, err
+	}
+
+	var deliveries []domain.WebhookDelivery
+	if len(claimed) == 0 {
+		return deliveries, nil
+	}
+
+	ids := make([]uint, len(claimed))
+	for i, delivery := range claimed {
+		ids[i] = delivery.ID
+	}
+
+	if err := db.Preload("Webhook").Where("id IN ?", ids).Order("id").Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (wr *WebhookRepository) GetDeliveries(ctx context.Context, webhookID uint, start, end uint64) ([]domain.WebhookDelivery, error) {
+	db := wr.db.Conn(ctx)
+
+	var deliveries []domain.WebhookDelivery
+	if err := db.Where("webhook_id = ?", webhookID).Order("id DESC").Offset(int(start)).Limit(int(end - start + 1)).Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (wr *WebhookRepository) GetDeliveryByID(ctx context.Context, id uint) (*domain.WebhookDelivery, error) {
+	db := wr.db.Conn(ctx)
+
+	var delivery domain.WebhookDelivery
+	if err := db.First(&delivery, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+func (wr *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) (*domain.WebhookDelivery, error) {
+	db := wr.db.Conn(ctx)
+
+	if err := db.Model(delivery).Select("status", "attempts", "next_attempt_at", "response_status", "response_body", "error", "delivered_at", "updated_at").Updates(delivery).Error; err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
@@ -0,0 +1,40 @@
+-- the schema gorm's AutoMigrate created before the versioned migrations
+CREATE TABLE users (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	updated_at timestamptz,
+	deleted_at timestamptz,
+	email varchar(255) NOT NULL CONSTRAINT uni_users_email UNIQUE,
+	password varchar(255) NOT NULL,
+	name varchar(255) NOT NULL,
+	role smallint NOT NULL DEFAULT 2001
+);
+CREATE INDEX idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE categories (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	updated_at timestamptz,
+	deleted_at timestamptz,
+	name varchar(100) NOT NULL CONSTRAINT uni_categories_name UNIQUE,
+	description text
+);
+CREATE INDEX idx_categories_deleted_at ON categories (deleted_at);
+
+CREATE TABLE posts (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	updated_at timestamptz,
+	deleted_at timestamptz,
+	category_id bigint NOT NULL CONSTRAINT fk_posts_category REFERENCES categories (id),
+	title varchar(255) NOT NULL,
+	content text NOT NULL,
+	published boolean DEFAULT false,
+	user_id bigint NOT NULL CONSTRAINT fk_posts_user REFERENCES users (id),
+	slug varchar(255) NOT NULL CONSTRAINT uni_posts_slug UNIQUE
+);
+CREATE INDEX idx_posts_deleted_at ON posts (deleted_at);
+
+INSERT INTO users (created_at, updated_at, email, password, name) VALUES (now(), now(), 'john@example.com', 'hash', 'John');
+INSERT INTO categories (created_at, updated_at, name) VALUES (now(), now(), 'Go');
+INSERT INTO posts (created_at, updated_at, category_id, title, content, published, user_id, slug) VALUES (now(), now(), 1, 'Hello', 'World', true, 1, 'hello');
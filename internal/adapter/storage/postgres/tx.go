@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// WithinTransaction runs fn in a transaction carried by the ctx passed to fn,
+// nested calls use savepoints
+func (d *DB) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return d.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// Conn returns the transaction of ctx, or the db if there is none
+func (d *DB) Conn(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+
+	return d.db.WithContext(ctx)
+}
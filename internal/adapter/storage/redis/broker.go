@@ -0,0 +1,215 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+const (
+	streamChannel    = "stream:messages"
+	streamSeqKey     = "stream:seq"
+	streamHistoryKey = "stream:history"
+
+	// streamSubscriberBuffer is the number of messages buffered per subscriber
+	streamSubscriberBuffer = 64
+)
+
+// Broker fans out stream messages with redis pub/sub, the latest messages are
+// kept in a sorted set scored by id so clients can resume after reconnecting.
+// The process shares one subscription, its messages are fanned out in memory
+type Broker struct {
+	client      redis.UniversalClient
+	historySize int64
+
+	// the keys and the channel are namespaced by app
+	channel    string
+	seqKey     string
+	historyKey string
+
+	mu          sync.Mutex
+	sub         *redis.PubSub
+	subscribers map[chan domain.StreamMessage]struct{}
+}
+
+func NewBroker(r *Redis, historySize int64) *Broker {
+	return &Broker{
+		client:      r.client,
+		historySize: historySize,
+		channel:     r.key(streamChannel),
+		seqKey:      r.key(streamSeqKey),
+		historyKey:  r.key(streamHistoryKey),
+		subscribers: map[chan domain.StreamMessage]struct{}{},
+	}
+}
+
+// publishScript assigns the next id to the message, adds it to the trimmed
+// history and publishes it at once, so the ids are published in order and no
+// message is published without being kept. ARGV[1] is the serialized message
+// after its id
+var publishScript = redis.NewScript(`
+local id = redis.call("INCR", KEYS[1])
+local msg = '{"id":' .. string.format("%d", id) .. ARGV[1]
+redis.call("ZADD", KEYS[2], id, msg)
+redis.call("ZREMRANGEBYRANK", KEYS[2], 0, -tonumber(ARGV[2]) - 1)
+redis.call("PUBLISH", ARGV[3], msg)
+return id
+`)
+
+func (b *Broker) Publish(ctx context.Context, msg *domain.StreamMessage) error {
+	rest, err := marshalStreamMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	id, err := publishScript.Run(ctx, b.client, []string{b.seqKey, b.historyKey}, rest, b.historySize, b.channel).Uint64()
+	if err != nil {
+		return err
+	}
+	msg.ID = id
+
+	return nil
+}
+
+// marshalStreamMessage serializes the message without its leading id field,
+// the id is only known once the publish script runs
+func marshalStreamMessage(msg *domain.StreamMessage) (string, error) {
+	unnumbered := *msg
+	unnumbered.ID = 0
+
+	serialized, err := json.Marshal(unnumbered)
+	if err != nil {
+		return "", err
+	}
+
+	rest, ok := strings.CutPrefix(string(serialized), `{"id":0`)
+	if !ok {
+		return "", fmt.Errorf("stream message doesn't start with its id: %s", serialized)
+	}
+
+	return rest, nil
+}
+
+func (b *Broker) Subscribe(ctx context.Context) (<-chan domain.StreamMessage, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.sub == nil {
+		if err := b.listen(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	msgs := make(chan domain.StreamMessage, streamSubscriberBuffer)
+	b.subscribers[msgs] = struct{}{}
+
+	// unsubscribe once ctx is cancelled
+	go func() {
+		<-ctx.Done()
+		b.unsubscribe(msgs)
+	}()
+
+	return msgs, nil
+}
+
+// Close closes the shared subscription and all subscribers
+func (b *Broker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for msgs := range b.subscribers {
+		delete(b.subscribers, msgs)
+		close(msgs)
+	}
+
+	if b.sub == nil {
+		return nil
+	}
+
+	err := b.sub.Close()
+	b.sub = nil
+
+	return err
+}
+
+// listen opens the shared subscription, the caller holds b.mu
+func (b *Broker) listen(ctx context.Context) error {
+	sub := b.client.Subscribe(ctx, b.channel)
+
+	// wait for the subscription so no message published afterwards is missed
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return err
+	}
+	b.sub = sub
+
+	// the channel outlives ctx, go-redis reconnects it until it's closed
+	go func() {
+		for redisMsg := range sub.Channel() {
+			var msg domain.StreamMessage
+			if err := json.Unmarshal([]byte(redisMsg.Payload), &msg); err != nil {
+				slog.Error("invalid stream message", "error", err)
+				continue
+			}
+
+			b.dispatch(msg)
+		}
+	}()
+
+	return nil
+}
+
+// dispatch sends the message to every subscriber, subscribers falling behind
+// are closed and resume from the history once their clients reconnect
+func (b *Broker) dispatch(msg domain.StreamMessage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for msgs := range b.subscribers {
+		select {
+		case msgs <- msg:
+		default:
+			slog.Warn("dropping slow stream subscriber", "message_id", msg.ID)
+			delete(b.subscribers, msgs)
+			close(msgs)
+		}
+	}
+}
+
+func (b *Broker) unsubscribe(msgs chan domain.StreamMessage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[msgs]; ok {
+		delete(b.subscribers, msgs)
+		close(msgs)
+	}
+}
+
+func (b *Broker) Since(ctx context.Context, lastID uint64) ([]domain.StreamMessage, error) {
+	res, err := b.client.ZRangeByScore(ctx, b.historyKey, &redis.ZRangeBy{
+		Min: "(" + strconv.FormatUint(lastID, 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]domain.StreamMessage, 0, len(res))
+	for _, serialized := range res {
+		var msg domain.StreamMessage
+		if err := json.Unmarshal([]byte(serialized), &msg); err != nil {
+			continue
+		}
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, nil
+}
@@ -0,0 +1,67 @@
+package redis
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+func TestBroker_Dispatch(t *testing.T) {
+	b := &Broker{subscribers: map[chan domain.StreamMessage]struct{}{}}
+
+	fast := make(chan domain.StreamMessage, streamSubscriberBuffer+1)
+	slow := make(chan domain.StreamMessage, streamSubscriberBuffer)
+	b.subscribers[fast] = struct{}{}
+	b.subscribers[slow] = struct{}{}
+
+	// one message more than the slow subscriber buffers
+	for i := range streamSubscriberBuffer + 1 {
+		b.dispatch(domain.StreamMessage{ID: uint64(i + 1)})
+	}
+
+	assert.Len(t, fast, streamSubscriberBuffer+1)
+	assert.NotContains(t, b.subscribers, slow)
+
+	// the slow subscriber gets the buffered messages, then its channel is closed
+	received := 0
+	for range slow {
+		received++
+	}
+	assert.Equal(t, streamSubscriberBuffer, received)
+
+	// unsubscribing closes the channel once
+	b.unsubscribe(fast)
+	b.unsubscribe(fast)
+	assert.Empty(t, b.subscribers)
+}
+
+func TestBroker_Close(t *testing.T) {
+	b := &Broker{subscribers: map[chan domain.StreamMessage]struct{}{}}
+
+	msgs := make(chan domain.StreamMessage, 1)
+	b.subscribers[msgs] = struct{}{}
+
+	assert.NoError(t, b.Close())
+	_, ok := <-msgs
+	assert.False(t, ok)
+
+	// subscribers cancelled afterwards are already closed
+	b.unsubscribe(msgs)
+}
+
+func TestMarshalStreamMessage(t *testing.T) {
+	msg := &domain.StreamMessage{ID: 7, Type: "notification", UserID: 3, Data: []byte(`{"id":1}`)}
+
+	rest, err := marshalStreamMessage(msg)
+	assert.NoError(t, err)
+
+	// the publish script prepends the assigned id
+	var published domain.StreamMessage
+	assert.NoError(t, json.Unmarshal([]byte(`{"id":42`+rest), &published))
+	assert.Equal(t, domain.StreamMessage{ID: 42, Type: "notification", UserID: 3, Data: []byte(`{"id":1}`)}, published)
+
+	// the message itself is left untouched
+	assert.Equal(t, uint64(7), msg.ID)
+}
@@ -0,0 +1,174 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+const (
+	eventStreamKey   = "events:stream"
+	eventGroup       = "events"
+	eventSeenPrefix  = "events:seen:"
+	eventSeenTTL     = 24 * time.Hour
+	eventBlock       = 5 * time.Second
+	eventClaimIdle   = time.Minute
+	eventReadCount   = 100
+	eventRetryPeriod = time.Second
+)
+
+// EventStream publishes events to a redis stream, replicas consume it in a
+// consumer group so each event is handled by one replica
+type EventStream struct {
+	client    redis.UniversalClient
+	key       string
+	maxLength int64
+	consumer  string
+}
+
+func NewEventStream(r *Redis, maxLength int64) *EventStream {
+	hostname, _ := os.Hostname()
+
+	return &EventStream{
+		r.client,
+		r.key(eventStreamKey),
+		maxLength,
+		fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+	}
+}
+
+func (es *EventStream) Publish(ctx context.Context, events ...domain.Event) error {
+	_, err := es.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, event := range events {
+			serialized, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+
+			pipe.XAdd(ctx, &redis.XAddArgs{
+				Stream: es.key,
+				MaxLen: es.maxLength,
+				Approx: true,
+				Values: map[string]any{"event": serialized},
+			})
+		}
+		return nil
+	})
+
+	return err
+}
+
+// Consume delivers the stream's events to the publisher until ctx is cancelled,
+// messages are acknowledged once delivered and claimed from crashed consumers
+func (es *EventStream) Consume(ctx context.Context, publisher port.EventPublisher) {
+	err := es.client.XGroupCreateMkStream(ctx, es.key, eventGroup, "0").Err()
+	if err != nil && !isBusyGroup(err) {
+		slog.Error("unable to create event consumer group", "error", err)
+		return
+	}
+
+	slog.Info("event consumer started", "consumer", es.consumer)
+
+	for ctx.Err() == nil {
+		if err := es.consume(ctx, publisher); err != nil && ctx.Err() == nil {
+			slog.Error("unable to consume events", "error", err)
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(eventRetryPeriod):
+			}
+		}
+	}
+
+	slog.Info("event consumer stopped", "consumer", es.consumer)
+}
+
+func (es *EventStream) consume(ctx context.Context, publisher port.EventPublisher) error {
+	// take over messages left pending by crashed consumers
+	claimed, _, err := es.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   es.key,
+		Group:    eventGroup,
+		Consumer: es.consumer,
+		MinIdle:  eventClaimIdle,
+		Start:    "0",
+		Count:    eventReadCount,
+	}).Result()
+	if err != nil {
+		return err
+	}
+
+	if err := es.deliver(ctx, publisher, claimed); err != nil {
+		return err
+	}
+
+	streams, err := es.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    eventGroup,
+		Consumer: es.consumer,
+		Streams:  []string{es.key, ">"},
+		Count:    eventReadCount,
+		Block:    eventBlock,
+	}).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil
+		}
+		return err
+	}
+
+	for _, stream := range streams {
+		if err := es.deliver(ctx, publisher, stream.Messages); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (es *EventStream) deliver(ctx context.Context, publisher port.EventPublisher, msgs []redis.XMessage) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(msgs))
+	events := make([]domain.Event, 0, len(msgs))
+	for _, msg := range msgs {
+		ids = append(ids, msg.ID)
+
+		serialized, _ := msg.Values["event"].(string)
+
+		var event domain.Event
+		if err := json.Unmarshal([]byte(serialized), &event); err != nil {
+			slog.Error("unable to decode event", "id", msg.ID, "error", err)
+			continue
+		}
+		events = append(events, event)
+	}
+
+	if err := publisher.Publish(ctx, events...); err != nil {
+		return err
+	}
+
+	return es.client.XAck(ctx, es.key, eventGroup, ids...).Err()
+}
+
+func isBusyGroup(err error) bool {
+	return strings.HasPrefix(err.Error(), "BUSYGROUP")
+}
+
+func (r *Redis) Seen(ctx context.Context, key string) (bool, error) {
+	n, err := r.client.Exists(ctx, r.key(eventSeenPrefix+key)).Result()
+	return n > 0, err
+}
+
+func (r *Redis) MarkSeen(ctx context.Context, key string) error {
+	return r.client.Set(ctx, r.key(eventSeenPrefix+key), 1, eventSeenTTL).Err()
+}
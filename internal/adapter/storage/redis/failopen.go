@@ -0,0 +1,540 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+// pending operations kept for replay, the namespace is flushed once exceeded
+const maxPendingOps = 10000
+
+var ErrCacheUnavailable = errors.New("cache is unavailable")
+
+type pendingOp struct {
+	key     string
+	prefix  bool
+	tag     bool
+	members []string
+}
+
+// FailOpenCache keeps the app serving from the db while redis is down. After
+// threshold consecutive failures the breaker opens: reads miss, writes are
+// dropped, and invalidations and dirty marks are queued. Redis is pinged in
+// the background and the queue is replayed before the breaker closes again.
+// Feeds and live messages go through the same breaker, feeds are rebuilt
+// from the db and live messages are dropped.
+type FailOpenCache struct {
+	r             *Redis
+	threshold     int
+	probeInterval time.Duration
+	// namespace prefixes the cached values, they're all flushed with the feeds
+	// when invalidations were lost
+	namespace string
+
+	mu       sync.Mutex
+	open     bool
+	probing  bool
+	failures int
+	pending  []pendingOp
+	queued   map[string]struct{}
+	lost     bool
+
+	errors  atomic.Uint64
+	dropped atomic.Uint64
+
+	stop chan struct{}
+}
+
+func NewFailOpenCache(r *Redis, threshold int, probeInterval time.Duration, namespace string) *FailOpenCache {
+	return &FailOpenCache{
+		r:             r,
+		threshold:     threshold,
+		probeInterval: probeInterval,
+		namespace:     namespace,
+		queued:        map[string]struct{}{},
+		stop:          make(chan struct{}),
+	}
+}
+
+func (fc *FailOpenCache) Set(ctx context.Context, key string, val []byte, ttl time.Duration) error {
+	if fc.isOpen() {
+		// an older value may still be stored
+		fc.drop(pendingOp{key: key})
+		return nil
+	}
+
+	if err := fc.result(ctx, fc.r.Set(ctx, key, val, ttl)); err != nil {
+		fc.drop(pendingOp{key: key})
+	}
+
+	return nil
+}
+
+func (fc *FailOpenCache) Get(ctx context.Context, key string) ([]byte, error) {
+	if fc.isOpen() {
+		return nil, ErrCacheUnavailable
+	}
+
+	val, err := fc.r.Get(ctx, key)
+	if err != nil {
+		return nil, fc.result(ctx, err)
+	}
+
+	return val, nil
+}
+
+func (fc *FailOpenCache) Delete(ctx context.Context, key string) error {
+	if fc.isOpen() {
+		fc.drop(pendingOp{key: key})
+		return nil
+	}
+
+	if err := fc.result(ctx, fc.r.Delete(ctx, key)); err != nil {
+		fc.drop(pendingOp{key: key})
+	}
+
+	return nil
+}
+
+func (fc *FailOpenCache) SetWithTags(ctx context.Context, key string, val []byte, ttl time.Duration, tags ...string) error {
+	if fc.isOpen() {
+		fc.drop(pendingOp{key: key})
+		return nil
+	}
+
+	if err := fc.result(ctx, fc.r.SetWithTags(ctx, key, val, ttl, tags...)); err != nil {
+		fc.drop(pendingOp{key: key})
+	}
+
+	return nil
+}
+
+func (fc *FailOpenCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	if fc.isOpen() {
+		for _, tag := range tags {
+			fc.drop(pendingOp{key: tag, tag: true})
+		}
+		return nil
+	}
+
+	if err := fc.result(ctx, fc.r.InvalidateTags(ctx, tags...)); err != nil {
+		for _, tag := range tags {
+			fc.drop(pendingOp{key: tag, tag: true})
+		}
+	}
+
+	return nil
+}
+
+func (fc *FailOpenCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	if fc.isOpen() {
+		fc.drop(pendingOp{key: prefix, prefix: true})
+		return nil
+	}
+
+	if err := fc.result(ctx, fc.r.DeleteByPrefix(ctx, prefix)); err != nil {
+		fc.drop(pendingOp{key: prefix, prefix: true})
+	}
+
+	return nil
+}
+
+func (fc *FailOpenCache) HIncrBy(ctx context.Context, key, field string, incr int64) (int64, error) {
+	if fc.isOpen() {
+		// the counters are reloaded from the db once the hash is gone
+		fc.drop(pendingOp{key: key})
+		return 0, nil
+	}
+
+	val, err := fc.r.HIncrBy(ctx, key, field, incr)
+	if err := fc.result(ctx, err); err != nil {
+		fc.drop(pendingOp{key: key})
+		return 0, nil
+	}
+
+	return val, nil
+}
+
+func (fc *FailOpenCache) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	if fc.isOpen() {
+		return map[string]string{}, nil
+	}
+
+	val, err := fc.r.HGetAll(ctx, key)
+	if err := fc.result(ctx, err); err != nil {
+		return map[string]string{}, nil
+	}
+
+	return val, nil
+}
+
+func (fc *FailOpenCache) HGetAllMulti(ctx context.Context, keys ...string) ([]map[string]string, error) {
+	misses := make([]map[string]string, len(keys))
+	for i := range misses {
+		misses[i] = map[string]string{}
+	}
+
+	if fc.isOpen() {
+		return misses, nil
+	}
+
+	val, err := fc.r.HGetAllMulti(ctx, keys...)
+	if err := fc.result(ctx, err); err != nil {
+		return misses, nil
+	}
+
+	return val, nil
+}
+
+func (fc *FailOpenCache) HSetNX(ctx context.Context, key string, fields map[string]int64) error {
+	if fc.isOpen() {
+		fc.drop(pendingOp{key: key})
+		return nil
+	}
+
+	if err := fc.result(ctx, fc.r.HSetNX(ctx, key, fields)); err != nil {
+		fc.drop(pendingOp{key: key})
+	}
+
+	return nil
+}
+
+func (fc *FailOpenCache) HReplace(ctx context.Context, key string, fields map[string]int64) error {
+	if fc.isOpen() {
+		fc.drop(pendingOp{key: key})
+		return nil
+	}
+
+	if err := fc.result(ctx, fc.r.HReplace(ctx, key, fields)); err != nil {
+		fc.drop(pendingOp{key: key})
+	}
+
+	return nil
+}
+
+func (fc *FailOpenCache) SAdd(ctx context.Context, key string, members ...string) error {
+	if fc.isOpen() {
+		fc.drop(pendingOp{key: key, members: members})
+		return nil
+	}
+
+	if err := fc.result(ctx, fc.r.SAdd(ctx, key, members...)); err != nil {
+		fc.drop(pendingOp{key: key, members: members})
+	}
+
+	return nil
+}
+
+func (fc *FailOpenCache) SPop(ctx context.Context, key string, count int64) ([]string, error) {
+	if fc.isOpen() {
+		return nil, nil
+	}
+
+	val, err := fc.r.SPop(ctx, key, count)
+	if err := fc.result(ctx, err); err != nil {
+		return nil, nil
+	}
+
+	return val, nil
+}
+
+func (fc *FailOpenCache) AddToFeeds(ctx context.Context, userIDs []uint, entry domain.FeedEntry, maxLength int64) error {
+	// the feeds missing the entry are unknown, so every feed is rebuilt once redis is back
+	if fc.isOpen() {
+		fc.drop(pendingOp{key: fc.r.key(feedKeyPrefix) + "*", prefix: true})
+		return nil
+	}
+
+	if err := fc.result(ctx, fc.r.AddToFeeds(ctx, userIDs, entry, maxLength)); err != nil {
+		fc.drop(pendingOp{key: fc.r.key(feedKeyPrefix) + "*", prefix: true})
+	}
+
+	return nil
+}
+
+// GetFeed reports a missing feed while redis is unavailable, so it's rebuilt from the db
+func (fc *FailOpenCache) GetFeed(ctx context.Context, userID uint, start, end uint64) ([]domain.FeedEntry, bool, error) {
+	if fc.isOpen() {
+		return nil, false, nil
+	}
+
+	entries, ok, err := fc.r.GetFeed(ctx, userID, start, end)
+	if err := fc.result(ctx, err); err != nil {
+		return nil, false, nil
+	}
+
+	return entries, ok, nil
+}
+
+func (fc *FailOpenCache) ReplaceFeed(ctx context.Context, userID uint, entries []domain.FeedEntry) error {
+	if fc.isOpen() {
+		fc.drop(pendingOp{key: fc.r.feedKey(userID)})
+		return nil
+	}
+
+	if err := fc.result(ctx, fc.r.ReplaceFeed(ctx, userID, entries)); err != nil {
+		fc.drop(pendingOp{key: fc.r.feedKey(userID)})
+	}
+
+	return nil
+}
+
+func (fc *FailOpenCache) DeleteFeed(ctx context.Context, userID uint) error {
+	if fc.isOpen() {
+		fc.drop(pendingOp{key: fc.r.feedKey(userID)})
+		return nil
+	}
+
+	if err := fc.result(ctx, fc.r.DeleteFeed(ctx, userID)); err != nil {
+		fc.drop(pendingOp{key: fc.r.feedKey(userID)})
+	}
+
+	return nil
+}
+
+// Publish drops live messages while redis is unavailable, readers only miss updates
+func (fc *FailOpenCache) Publish(ctx context.Context, topic string, payload []byte) error {
+	if fc.isOpen() {
+		fc.dropped.Add(1)
+		return nil
+	}
+
+	if err := fc.result(ctx, fc.r.Publish(ctx, topic, payload)); err != nil {
+		fc.dropped.Add(1)
+	}
+
+	return nil
+}
+
+func (fc *FailOpenCache) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	if fc.isOpen() {
+		return nil, ErrCacheUnavailable
+	}
+
+	msgs, err := fc.r.Subscribe(ctx, topic)
+	if err != nil {
+		return nil, fc.result(ctx, err)
+	}
+
+	return msgs, nil
+}
+
+// Close stops probing, the redis client is closed by its owner
+func (fc *FailOpenCache) Close() error {
+	close(fc.stop)
+	return nil
+}
+
+// Errors returns the number of failed cache operations
+func (fc *FailOpenCache) Errors() uint64 {
+	return fc.errors.Load()
+}
+
+// Dropped returns the number of writes dropped while redis was unavailable
+func (fc *FailOpenCache) Dropped() uint64 {
+	return fc.dropped.Load()
+}
+
+func (fc *FailOpenCache) isOpen() bool {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	return fc.open
+}
+
+// result counts redis failures, opening the breaker after too many in a row
+func (fc *FailOpenCache) result(ctx context.Context, err error) error {
+	if err == nil || errors.Is(err, redis.Nil) {
+		fc.mu.Lock()
+		fc.failures = 0
+		fc.mu.Unlock()
+		return err
+	}
+
+	// the caller giving up isn't a redis failure
+	if ctx.Err() != nil {
+		return err
+	}
+
+	total := fc.errors.Add(1)
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	fc.failures++
+	slog.Warn("cache operation failed", "error", err, "consecutive", fc.failures, "total", total)
+
+	if !fc.open && fc.failures >= fc.threshold {
+		fc.open = true
+		slog.Error("cache unavailable, serving from the database", "error", err)
+		fc.startProbe()
+	}
+
+	return err
+}
+
+func (fc *FailOpenCache) drop(op pendingOp) {
+	fc.dropped.Add(1)
+
+	id := opID(op)
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	if id != "" {
+		if _, ok := fc.queued[id]; ok {
+			return
+		}
+	}
+
+	if len(fc.pending) >= maxPendingOps {
+		if !fc.lost {
+			slog.Error("cache replay queue full, the cache is flushed once redis is back")
+		}
+		fc.lost = true
+		return
+	}
+
+	if id != "" {
+		fc.queued[id] = struct{}{}
+	}
+	fc.pending = append(fc.pending, op)
+
+	// replay writes dropped before the breaker opened as well
+	fc.startProbe()
+}
+
+// startProbe must be called with mu held
+func (fc *FailOpenCache) startProbe() {
+	if fc.probing {
+		return
+	}
+
+	fc.probing = true
+	go fc.probe()
+}
+
+// probe pings redis until it answers, then replays the queue and closes the breaker
+func (fc *FailOpenCache) probe() {
+	ticker := time.NewTicker(fc.probeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-fc.stop:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), fc.probeInterval)
+		err := fc.r.client.Ping(ctx).Err()
+		if err == nil {
+			err = fc.replay(ctx)
+		}
+		cancel()
+
+		if err != nil {
+			slog.Debug("cache still unavailable", "error", err)
+			continue
+		}
+
+		slog.Info("cache available", "errors", fc.Errors(), "dropped", fc.Dropped())
+		return
+	}
+}
+
+// replay applies the queued operations, closing the breaker once the queue is
+// empty. Lost operations flush the namespace instead, they're unknown
+func (fc *FailOpenCache) replay(ctx context.Context) error {
+	for {
+		fc.mu.Lock()
+		if fc.lost {
+			fc.mu.Unlock()
+
+			if err := fc.flush(ctx); err != nil {
+				return err
+			}
+
+			// the queue still holds the dirty marks, it's replayed next
+			fc.mu.Lock()
+			fc.lost = false
+			fc.mu.Unlock()
+			continue
+		}
+
+		ops := fc.pending
+		fc.pending = nil
+		fc.queued = map[string]struct{}{}
+		if len(ops) == 0 {
+			fc.open = false
+			fc.probing = false
+			fc.failures = 0
+			fc.mu.Unlock()
+			return nil
+		}
+		fc.mu.Unlock()
+
+		for i, op := range ops {
+			var err error
+			switch {
+			case op.prefix:
+				err = fc.r.DeleteByPrefix(ctx, op.key)
+			case op.tag:
+				err = fc.r.InvalidateTags(ctx, op.key)
+			case op.members != nil:
+				err = fc.r.SAdd(ctx, op.key, op.members...)
+			default:
+				err = fc.r.Delete(ctx, op.key)
+			}
+
+			if err != nil {
+				// requeue what's left for the next probe
+				fc.mu.Lock()
+				fc.pending = append(ops[i:], fc.pending...)
+				for _, op := range fc.pending {
+					if id := opID(op); id != "" {
+						fc.queued[id] = struct{}{}
+					}
+				}
+				fc.mu.Unlock()
+				return err
+			}
+		}
+	}
+}
+
+// flush deletes the cached values and tags of the namespace, and the app's feeds.
+// Hashes and sets hold counters and dirty marks, they're left to the queue
+func (fc *FailOpenCache) flush(ctx context.Context) error {
+	patterns := []string{fc.namespace + "*", tagKeyPrefix + fc.namespace + "*", fc.r.key(feedKeyPrefix) + "*"}
+	for _, pattern := range patterns {
+		if err := fc.r.DeleteByPrefix(ctx, pattern); err != nil {
+			return err
+		}
+	}
+
+	slog.Warn("cache flushed after losing invalidations", "namespace", fc.namespace)
+
+	return nil
+}
+
+// opID identifies repeated invalidations, dirty marks are all kept since their members differ
+func opID(op pendingOp) string {
+	switch {
+	case op.prefix:
+		return "prefix:" + op.key
+	case op.tag:
+		return "tag:" + op.key
+	case op.members != nil:
+		return ""
+	default:
+		return op.key
+	}
+}
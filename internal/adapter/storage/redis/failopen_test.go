@@ -0,0 +1,109 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+// unreachable returns a client that fails fast, nothing listens on port 1
+func unreachable() *Redis {
+	return &Redis{
+		client: redis.NewClient(&redis.Options{
+			Addr:        "127.0.0.1:1",
+			DialTimeout: 50 * time.Millisecond,
+			MaxRetries:  -1,
+		}),
+		namespace: "app:",
+	}
+}
+
+func TestFailOpenCache_Unavailable(t *testing.T) {
+	ctx := context.Background()
+
+	fc := NewFailOpenCache(unreachable(), 2, time.Hour, "app:v1:")
+	defer fc.Close()
+
+	// writes never fail the caller
+	assert.NoError(t, fc.Set(ctx, "post:1", []byte("{}"), time.Minute))
+	assert.NoError(t, fc.Delete(ctx, "post:1"))
+	assert.True(t, fc.isOpen())
+	assert.Equal(t, uint64(2), fc.Errors())
+
+	// reads miss without touching redis once the breaker is open
+	_, err := fc.Get(ctx, "post:1")
+	assert.ErrorIs(t, err, ErrCacheUnavailable)
+	assert.Equal(t, uint64(2), fc.Errors())
+
+	assert.NoError(t, fc.DeleteByPrefix(ctx, "posts:*"))
+	assert.NoError(t, fc.InvalidateTags(ctx, "list:posts"))
+	assert.NoError(t, fc.SAdd(ctx, "dirty", "1"))
+
+	counts, err := fc.HGetAll(ctx, "counts")
+	assert.NoError(t, err)
+	assert.Empty(t, counts)
+
+	// repeated invalidations are queued once, dirty marks are all kept
+	assert.NoError(t, fc.SAdd(ctx, "dirty", "2"))
+	assert.NoError(t, fc.Delete(ctx, "post:1"))
+	assert.NoError(t, fc.InvalidateTags(ctx, "list:posts"))
+	assert.Equal(t, uint64(8), fc.Dropped())
+	assert.Len(t, fc.pending, 5)
+}
+
+func TestFailOpenCache_FeedUnavailable(t *testing.T) {
+	ctx := context.Background()
+
+	fc := NewFailOpenCache(unreachable(), 1, time.Hour, "app:v1:")
+	defer fc.Close()
+
+	// a feed that can't be read is reported missing so it's rebuilt from the db
+	entries, ok, err := fc.GetFeed(ctx, 1, 0, 9)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Empty(t, entries)
+	assert.True(t, fc.isOpen())
+
+	// writes never fail the caller, fan-outs lost while open rebuild every feed once
+	entry := domain.FeedEntry{PostID: 1, PublishedAt: time.Now()}
+	assert.NoError(t, fc.AddToFeeds(ctx, []uint{1, 2}, entry, 100))
+	assert.NoError(t, fc.AddToFeeds(ctx, []uint{3}, entry, 100))
+	assert.NoError(t, fc.ReplaceFeed(ctx, 1, []domain.FeedEntry{entry}))
+	assert.NoError(t, fc.DeleteFeed(ctx, 1))
+	assert.Len(t, fc.pending, 2)
+
+	// only the app's own feeds are dropped, other apps may share redis
+	assert.Equal(t, "app:feed:*", fc.pending[0].key)
+	assert.Equal(t, "app:feed:1", fc.pending[1].key)
+
+	// live messages are dropped, subscribing fails
+	assert.NoError(t, fc.Publish(ctx, "live:comments:1", []byte("{}")))
+	_, err = fc.Subscribe(ctx, "live:comments:1")
+	assert.ErrorIs(t, err, ErrCacheUnavailable)
+}
+
+func TestFailOpenCache_QueueFull(t *testing.T) {
+	ctx := context.Background()
+
+	fc := NewFailOpenCache(unreachable(), 1, time.Hour, "app:v1:")
+	defer fc.Close()
+
+	// one invalidation more than the queue holds
+	for i := range maxPendingOps + 1 {
+		assert.NoError(t, fc.Delete(ctx, "post:"+strconv.Itoa(i)))
+	}
+	assert.Len(t, fc.pending, maxPendingOps)
+	assert.True(t, fc.lost)
+
+	// the lost invalidation is unknown, the namespace is flushed before the
+	// queue is replayed and the breaker stays open until that succeeds
+	assert.Error(t, fc.replay(ctx))
+	assert.True(t, fc.lost)
+	assert.True(t, fc.isOpen())
+	assert.Len(t, fc.pending, maxPendingOps)
+}
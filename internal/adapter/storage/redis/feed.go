@@ -0,0 +1,108 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+// feeds are sorted sets of post ids scored by their publish time
+const feedKeyPrefix = "feed:"
+
+func (r *Redis) feedKey(userID uint) string {
+	return r.key(feedKeyPrefix + strconv.FormatUint(uint64(userID), 10))
+}
+
+// only add to stored feeds, missing feeds are rebuilt when read
+var addToFeedScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 1 then
+	redis.call("ZADD", KEYS[1], ARGV[1], ARGV[2])
+	redis.call("ZREMRANGEBYRANK", KEYS[1], 0, -tonumber(ARGV[3]) - 1)
+end
+return 0
+`)
+
+func (r *Redis) AddToFeeds(ctx context.Context, userIDs []uint, entry domain.FeedEntry, maxLength int64) error {
+	score := entry.PublishedAt.UnixMilli()
+	member := strconv.FormatUint(uint64(entry.PostID), 10)
+
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, userID := range userIDs {
+			addToFeedScript.Eval(ctx, pipe, []string{r.feedKey(userID)}, score, member, maxLength)
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *Redis) GetFeed(ctx context.Context, userID uint, start, end uint64) ([]domain.FeedEntry, bool, error) {
+	key := r.feedKey(userID)
+
+	exists, err := r.client.Exists(ctx, key).Result()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if exists == 0 {
+		return nil, false, nil
+	}
+
+	res, err := r.client.ZRevRangeWithScores(ctx, key, int64(start), int64(end)).Result()
+	if err != nil {
+		return nil, false, err
+	}
+
+	entries := make([]domain.FeedEntry, 0, len(res))
+	for _, z := range res {
+		member, _ := z.Member.(string)
+
+		// skip the marker of an empty feed
+		if member == emptyFeedMember {
+			continue
+		}
+
+		postID, err := strconv.ParseUint(member, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		entries = append(entries, domain.FeedEntry{
+			PostID:      uint(postID),
+			PublishedAt: time.UnixMilli(int64(z.Score)),
+		})
+	}
+
+	return entries, true, nil
+}
+
+// emptyFeedMember keeps the key of a feed without posts, it scores lowest so it's never returned within a page of posts
+const emptyFeedMember = "-"
+
+func (r *Redis) ReplaceFeed(ctx context.Context, userID uint, entries []domain.FeedEntry) error {
+	key := r.feedKey(userID)
+
+	members := make([]redis.Z, 0, len(entries)+1)
+	members = append(members, redis.Z{Score: 0, Member: emptyFeedMember})
+	for _, entry := range entries {
+		members = append(members, redis.Z{
+			Score:  float64(entry.PublishedAt.UnixMilli()),
+			Member: strconv.FormatUint(uint64(entry.PostID), 10),
+		})
+	}
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.ZAdd(ctx, key, members...)
+		return nil
+	})
+
+	return err
+}
+
+func (r *Redis) DeleteFeed(ctx context.Context, userID uint) error {
+	return r.client.Del(ctx, r.feedKey(userID)).Err()
+}
@@ -0,0 +1,60 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// only delete the lock if it is still owned by the caller
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// only extend the lock if it is still owned by the caller
+var renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+func (r *Redis) TryLock(ctx context.Context, key string, ttl time.Duration) (string, bool, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", false, err
+	}
+	token := hex.EncodeToString(buf)
+
+	ok, err := r.client.SetNX(ctx, r.key(key), token, ttl).Result()
+	if err != nil {
+		return "", false, err
+	}
+
+	return token, ok, nil
+}
+
+func (r *Redis) RenewLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	renewed, err := renewScript.Run(ctx, r.client, []string{r.key(key)}, token, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+
+	return renewed == 1, nil
+}
+
+func (r *Redis) Unlock(ctx context.Context, key, token string) error {
+	err := unlockScript.Run(ctx, r.client, []string{r.key(key)}, token).Err()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package redis
+
+import "context"
+
+func (r *Redis) Publish(ctx context.Context, topic string, payload []byte) error {
+	return r.client.Publish(ctx, topic, payload).Err()
+}
+
+func (r *Redis) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	sub := r.client.Subscribe(ctx, topic)
+
+	// wait for the subscription so no message published afterwards is missed
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return nil, err
+	}
+
+	msgs := make(chan []byte)
+	go func() {
+		defer close(msgs)
+		defer sub.Close()
+
+		ch := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+
+				select {
+				case msgs <- []byte(msg.Payload):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return msgs, nil
+}
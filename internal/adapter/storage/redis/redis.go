@@ -2,37 +2,161 @@ package redis
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/config"
 )
 
+// Redis wraps a standalone, sentinel or cluster client
 type Redis struct {
-	client *redis.Client
+	client redis.UniversalClient
+	// namespace prefixes the app's own keys so apps can share one redis,
+	// cached values are namespaced by the caller
+	namespace string
 }
 
-func New(ctx context.Context, conf *config.Redis) (*Redis, error) {
-	uri := conf.Host + ":" + conf.Port
+func New(ctx context.Context, conf *config.Redis, namespace string) (*Redis, error) {
+	opts, err := newOptions(conf)
+	if err != nil {
+		return nil, err
+	}
 
 	// connect with redis
-	client := redis.NewClient(&redis.Options{
-		Addr:     uri,
-		Password: conf.Password,
-		DB:       0, // use default DB
-		Protocol: 2,
-	})
+	var client redis.UniversalClient
+	switch conf.Mode {
+	case "", "standalone":
+		client = redis.NewClient(opts.Simple())
+	case "sentinel":
+		if opts.MasterName == "" {
+			return nil, errors.New("redis sentinel mode requires a master name")
+		}
+		client = redis.NewFailoverClient(opts.Failover())
+	case "cluster":
+		if opts.DB != 0 {
+			return nil, errors.New("redis cluster mode only supports db 0")
+		}
+		client = redis.NewClusterClient(opts.Cluster())
+	default:
+		return nil, errors.New("invalid redis mode: " + conf.Mode)
+	}
 
-	// test conn
+	// test conn, the app starts without redis and the client reconnects once it's up
 	if _, err := client.Ping(ctx).Result(); err != nil {
-		return nil, err
+		slog.Warn("redis unavailable", "error", err)
 	}
 
 	return &Redis{
 		client,
+		namespace,
 	}, nil
 }
 
+// key prefixes the key with the app's namespace
+func (r *Redis) key(key string) string {
+	return r.namespace + key
+}
+
+// newOptions parses the connection settings, empty values keep go-redis' defaults
+func newOptions(conf *config.Redis) (*redis.UniversalOptions, error) {
+	opts := &redis.UniversalOptions{
+		Addrs:            []string{conf.Host + ":" + conf.Port},
+		MasterName:       conf.MasterName,
+		SentinelPassword: conf.SentinelPassword,
+		Username:         conf.Username,
+		Password:         conf.Password,
+		Protocol:         2,
+	}
+
+	if conf.Addrs != "" {
+		opts.Addrs = nil
+		for _, addr := range strings.Split(conf.Addrs, ",") {
+			if addr = strings.TrimSpace(addr); addr != "" {
+				opts.Addrs = append(opts.Addrs, addr)
+			}
+		}
+	}
+
+	ints := []struct {
+		val string
+		dst *int
+	}{
+		{conf.DB, &opts.DB},
+		{conf.PoolSize, &opts.PoolSize},
+		{conf.MinIdleConns, &opts.MinIdleConns},
+	}
+	for _, i := range ints {
+		if i.val == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(i.val)
+		if err != nil {
+			return nil, err
+		}
+		*i.dst = n
+	}
+
+	timeouts := []struct {
+		val string
+		dst *time.Duration
+	}{
+		{conf.DialTimeout, &opts.DialTimeout},
+		{conf.ReadTimeout, &opts.ReadTimeout},
+		{conf.WriteTimeout, &opts.WriteTimeout},
+	}
+	for _, timeout := range timeouts {
+		if timeout.val == "" {
+			continue
+		}
+
+		ms, err := strconv.Atoi(timeout.val)
+		if err != nil {
+			return nil, err
+		}
+		*timeout.dst = time.Duration(ms) * time.Millisecond
+	}
+
+	if conf.TLS == "true" {
+		tlsConf, err := newTLSConfig(conf)
+		if err != nil {
+			return nil, err
+		}
+		opts.TLSConfig = tlsConf
+	}
+
+	return opts, nil
+}
+
+func newTLSConfig(conf *config.Redis) (*tls.Config, error) {
+	tlsConf := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         conf.TLSServerName,
+		InsecureSkipVerify: conf.TLSSkipVerify == "true",
+	}
+
+	if conf.TLSCAFile != "" {
+		pem, err := os.ReadFile(conf.TLSCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConf.RootCAs = x509.NewCertPool()
+		if !tlsConf.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates in redis ca file: " + conf.TLSCAFile)
+		}
+	}
+
+	return tlsConf, nil
+}
+
 func (r *Redis) Set(ctx context.Context, key string, val []byte, ttl time.Duration) error {
 	return r.client.Set(ctx, key, val, ttl).Err()
 }
@@ -50,20 +174,34 @@ func (r *Redis) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
 
+// DeleteByPrefix deletes the keys matching the pattern, a cluster is scanned
+// node by node since SCAN only covers the node it's sent to
 func (r *Redis) DeleteByPrefix(ctx context.Context, prefix string) error {
+	if cluster, ok := r.client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return deleteByPrefix(ctx, node, prefix, true)
+		})
+	}
+
+	return deleteByPrefix(ctx, r.client, prefix, false)
+}
+
+// deleteByPrefix scans one node, keys in different hash slots can't be
+// unlinked by one command so a cluster node's keys are unlinked one by one
+func deleteByPrefix(ctx context.Context, client redis.Cmdable, prefix string, cluster bool) error {
 	var cursor uint64
 	var keys []string
 
 	for {
 		var err error
-		keys, cursor, err = r.client.Scan(ctx, cursor, prefix, 100).Result()
+		keys, cursor, err = client.Scan(ctx, cursor, prefix, 100).Result()
 		if err != nil {
 			return err
 		}
 
-		for _, key := range keys {
-			err := r.client.Del(ctx, key).Err()
-			if err != nil {
+		// delete each page of keys at once
+		if len(keys) > 0 {
+			if err := unlink(ctx, client, keys, cluster); err != nil {
 				return err
 			}
 		}
@@ -76,6 +214,107 @@ func (r *Redis) DeleteByPrefix(ctx context.Context, prefix string) error {
 	return nil
 }
 
+// unlink deletes the keys in one command, or in one pipeline of single key
+// commands when they may be spread over hash slots
+func unlink(ctx context.Context, client redis.Cmdable, keys []string, cluster bool) error {
+	if !cluster {
+		return client.Unlink(ctx, keys...).Err()
+	}
+
+	_, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Unlink(ctx, key)
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *Redis) HIncrBy(ctx context.Context, key, field string, incr int64) (int64, error) {
+	return r.client.HIncrBy(ctx, key, field, incr).Result()
+}
+
+func (r *Redis) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	return r.client.HGetAll(ctx, key).Result()
+}
+
+func (r *Redis) HGetAllMulti(ctx context.Context, keys ...string) ([]map[string]string, error) {
+	cmds := make([]*redis.MapStringStringCmd, len(keys))
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.HGetAll(ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]map[string]string, len(cmds))
+	for i, cmd := range cmds {
+		hashes[i] = cmd.Val()
+	}
+
+	return hashes, nil
+}
+
+// hSetNXScript sets each field with HSETNX in one script, so a concurrent
+// HINCRBY lands either before or after the whole load
+var hSetNXScript = redis.NewScript(`
+for i = 1, #ARGV, 2 do
+	redis.call("HSETNX", KEYS[1], ARGV[i], ARGV[i + 1])
+end
+return 0
+`)
+
+func (r *Redis) HSetNX(ctx context.Context, key string, fields map[string]int64) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return hSetNXScript.Run(ctx, r.client, []string{key}, hashArgs(fields)...).Err()
+}
+
+func (r *Redis) HReplace(ctx context.Context, key string, fields map[string]int64) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		if len(fields) > 0 {
+			pipe.HSet(ctx, key, hashArgs(fields)...)
+		}
+		return nil
+	})
+
+	return err
+}
+
+// hashArgs flattens the fields into field, value pairs
+func hashArgs(fields map[string]int64) []any {
+	args := make([]any, 0, len(fields)*2)
+	for field, val := range fields {
+		args = append(args, field, val)
+	}
+
+	return args
+}
+
+func (r *Redis) SAdd(ctx context.Context, key string, members ...string) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	vals := make([]any, len(members))
+	for i, member := range members {
+		vals[i] = member
+	}
+
+	return r.client.SAdd(ctx, key, vals...).Err()
+}
+
+func (r *Redis) SPop(ctx context.Context, key string, count int64) ([]string, error) {
+	return r.client.SPopN(ctx, key, count).Result()
+}
+
 func (r *Redis) Close() error {
 	return r.client.Close()
 }
@@ -0,0 +1,78 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/config"
+)
+
+func TestNewOptions(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		conf     config.Redis
+		expected func(t *testing.T, opts *redis.UniversalOptions)
+		err      bool
+	}{
+		{
+			desc: "Defaults",
+			conf: config.Redis{Host: "127.0.0.1", Port: "6379"},
+			expected: func(t *testing.T, opts *redis.UniversalOptions) {
+				assert.Equal(t, []string{"127.0.0.1:6379"}, opts.Addrs)
+				assert.Zero(t, opts.DB)
+				assert.Zero(t, opts.PoolSize)
+				assert.Nil(t, opts.TLSConfig)
+			},
+		},
+		{
+			desc: "Sentinel",
+			conf: config.Redis{
+				Host:         "127.0.0.1",
+				Port:         "6379",
+				Addrs:        "10.0.0.1:26379, 10.0.0.2:26379,",
+				MasterName:   "primary",
+				Username:     "app",
+				DB:           "2",
+				PoolSize:     "20",
+				MinIdleConns: "4",
+				ReadTimeout:  "500",
+				TLS:          "true",
+			},
+			expected: func(t *testing.T, opts *redis.UniversalOptions) {
+				assert.Equal(t, []string{"10.0.0.1:26379", "10.0.0.2:26379"}, opts.Addrs)
+				assert.Equal(t, "primary", opts.Failover().MasterName)
+				assert.Equal(t, "app", opts.Username)
+				assert.Equal(t, 2, opts.DB)
+				assert.Equal(t, 20, opts.PoolSize)
+				assert.Equal(t, 4, opts.MinIdleConns)
+				assert.Equal(t, 500*time.Millisecond, opts.ReadTimeout)
+				assert.NotNil(t, opts.TLSConfig)
+			},
+		},
+		{
+			desc: "Fail_InvalidDB",
+			conf: config.Redis{DB: "one"},
+			err:  true,
+		},
+		{
+			desc: "Fail_MissingCAFile",
+			conf: config.Redis{TLS: "true", TLSCAFile: "/nonexistent/ca.pem"},
+			err:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			opts, err := newOptions(&tc.conf)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			tc.expected(t, opts)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+// the spam model keys share a hash tag so they're trained atomically in cluster mode
+const (
+	spamStatsKey      = "{spam}:stats"
+	spamVocabularyKey = "{spam}:vocabulary"
+	spamWordsKey      = "{spam}:words:spam"
+	hamWordsKey       = "{spam}:words:ham"
+)
+
+func (r *Redis) TrainSpamModel(ctx context.Context, spam bool, tokens []string) error {
+	countField, totalField, wordsKey := "ham_count", "ham_total", hamWordsKey
+	if spam {
+		countField, totalField, wordsKey = "spam_count", "spam_total", spamWordsKey
+	}
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HIncrBy(ctx, spamStatsKey, countField, 1)
+		if len(tokens) == 0 {
+			return nil
+		}
+
+		pipe.HIncrBy(ctx, spamStatsKey, totalField, int64(len(tokens)))
+		for _, token := range tokens {
+			pipe.HIncrBy(ctx, wordsKey, token, 1)
+		}
+
+		members := make([]any, len(tokens))
+		for i, token := range tokens {
+			members[i] = token
+		}
+		pipe.SAdd(ctx, spamVocabularyKey, members...)
+
+		return nil
+	})
+
+	return err
+}
+
+func (r *Redis) GetSpamModel(ctx context.Context, tokens []string) (*domain.SpamModel, error) {
+	var (
+		stats      *redis.MapStringStringCmd
+		vocabulary *redis.IntCmd
+		spamWords  *redis.SliceCmd
+		hamWords   *redis.SliceCmd
+	)
+
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		stats = pipe.HGetAll(ctx, spamStatsKey)
+		vocabulary = pipe.SCard(ctx, spamVocabularyKey)
+		if len(tokens) > 0 {
+			spamWords = pipe.HMGet(ctx, spamWordsKey, tokens...)
+			hamWords = pipe.HMGet(ctx, hamWordsKey, tokens...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var counts struct {
+		SpamCount int64 `redis:"spam_count"`
+		HamCount  int64 `redis:"ham_count"`
+		SpamTotal int64 `redis:"spam_total"`
+		HamTotal  int64 `redis:"ham_total"`
+	}
+	if err := stats.Scan(&counts); err != nil {
+		return nil, err
+	}
+
+	model := &domain.SpamModel{
+		SpamCount:  counts.SpamCount,
+		HamCount:   counts.HamCount,
+		SpamTotal:  counts.SpamTotal,
+		HamTotal:   counts.HamTotal,
+		Vocabulary: vocabulary.Val(),
+		SpamWords:  map[string]int64{},
+		HamWords:   map[string]int64{},
+	}
+
+	if len(tokens) > 0 {
+		wordCounts(model.SpamWords, tokens, spamWords.Val())
+		wordCounts(model.HamWords, tokens, hamWords.Val())
+	}
+
+	return model, nil
+}
+
+// wordCounts maps the HMGET values to their tokens, missing words aren't set
+func wordCounts(words map[string]int64, tokens []string, vals []any) {
+	for i, val := range vals {
+		s, ok := val.(string)
+		if !ok {
+			continue
+		}
+
+		count, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			continue
+		}
+		words[tokens[i]] = count
+	}
+}
@@ -0,0 +1,141 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tagKeyPrefix namespaces the sets holding the keys of each tag
+const tagKeyPrefix = "cache-tags:"
+
+// setWithTagsScript stores KEYS[1] and adds it to the tag sets in KEYS[2:]. A
+// tag set lives as long as its longest lived key, ARGV[2] is the ttl in ms,
+// 0 for none
+var setWithTagsScript = redis.NewScript(`
+local ttl = tonumber(ARGV[2])
+if ttl > 0 then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ttl)
+else
+	redis.call('SET', KEYS[1], ARGV[1])
+end
+
+for i = 2, #KEYS do
+	local current = redis.call('PTTL', KEYS[i])
+	redis.call('SADD', KEYS[i], KEYS[1])
+	if ttl == 0 then
+		redis.call('PERSIST', KEYS[i])
+	elseif current == -2 or (current >= 0 and current < ttl) then
+		redis.call('PEXPIRE', KEYS[i], ttl)
+	end
+end
+
+return 1
+`)
+
+// invalidateTagsScript deletes the keys in the tag sets in KEYS and the sets themselves
+var invalidateTagsScript = redis.NewScript(`
+local deleted = 0
+for _, tag in ipairs(KEYS) do
+	local keys = redis.call('SMEMBERS', tag)
+	for i = 1, #keys, 500 do
+		deleted = deleted + redis.call('UNLINK', unpack(keys, i, math.min(i + 499, #keys)))
+	end
+	redis.call('UNLINK', tag)
+end
+
+return deleted
+`)
+
+// tagKeyScript adds ARGV[1] to the tag set in KEYS[1] like setWithTagsScript,
+// it only touches the set so it runs in a cluster where a key and its tags
+// hash to different slots
+var tagKeyScript = redis.NewScript(`
+local ttl = tonumber(ARGV[2])
+local current = redis.call('PTTL', KEYS[1])
+redis.call('SADD', KEYS[1], ARGV[1])
+if ttl == 0 then
+	redis.call('PERSIST', KEYS[1])
+elseif current == -2 or (current >= 0 and current < ttl) then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+end
+
+return 1
+`)
+
+func (r *Redis) SetWithTags(ctx context.Context, key string, val []byte, ttl time.Duration, tags ...string) error {
+	if len(tags) == 0 {
+		return r.Set(ctx, key, val, ttl)
+	}
+
+	if _, ok := r.client.(*redis.ClusterClient); ok {
+		// tag the key before storing it, so a failure in between leaves a
+		// dangling tag member rather than a key invalidations can't reach
+		for _, tag := range tagKeys(tags) {
+			if err := tagKeyScript.Run(ctx, r.client, []string{tag}, key, ttl.Milliseconds()).Err(); err != nil {
+				return err
+			}
+		}
+
+		return r.Set(ctx, key, val, ttl)
+	}
+
+	keys := append([]string{key}, tagKeys(tags)...)
+	return setWithTagsScript.Run(ctx, r.client, keys, val, ttl.Milliseconds()).Err()
+}
+
+func (r *Redis) InvalidateTags(ctx context.Context, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	if _, ok := r.client.(*redis.ClusterClient); ok {
+		for _, tag := range tagKeys(tags) {
+			if err := r.invalidateClusterTag(ctx, tag); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return invalidateTagsScript.Run(ctx, r.client, tagKeys(tags)).Err()
+}
+
+// invalidateClusterTag deletes the tagged keys from their slots. Only the read
+// members are removed from the tag and before their keys are deleted, so a key
+// tagged again meanwhile stays in the tag.
+func (r *Redis) invalidateClusterTag(ctx context.Context, tag string) error {
+	keys, err := r.client.SMembers(ctx, tag).Result()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(keys); i += 500 {
+		batch := keys[i:min(i+500, len(keys))]
+
+		members := make([]any, len(batch))
+		for j, key := range batch {
+			members[j] = key
+		}
+		if err := r.client.SRem(ctx, tag, members...).Err(); err != nil {
+			return err
+		}
+
+		if err := unlink(ctx, r.client, batch, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func tagKeys(tags []string) []string {
+	keys := make([]string, len(tags))
+	for i, tag := range tags {
+		keys[i] = tagKeyPrefix + tag
+	}
+
+	return keys
+}
@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+// maximum response body size kept in the delivery log
+const maxResponseBody = 4096
+
+// HTTPSender posts deliveries to the webhook urls
+type HTTPSender struct {
+	client *http.Client
+}
+
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	return &HTTPSender{
+		&http.Client{Timeout: timeout},
+	}
+}
+
+func (hs *HTTPSender) Send(ctx context.Context, req *domain.WebhookRequest) (*domain.WebhookResponse, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	for key, val := range req.Headers {
+		httpReq.Header.Set(key, val)
+	}
+
+	res, err := hs.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxResponseBody))
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.WebhookResponse{
+		Status: res.StatusCode,
+		Body:   string(body),
+	}, nil
+}
@@ -0,0 +1,25 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+// NewOutboxRelay publishes the events stored in the outbox
+func NewOutboxRelay(interval time.Duration, lock port.LockRepository, svc port.OutboxService) *Worker {
+	return New("outbox-relay", interval, lock, func(ctx context.Context) error {
+		relayed, err := svc.Relay(ctx)
+		if err != nil {
+			return err
+		}
+
+		if relayed > 0 {
+			slog.Debug("outbox events relayed", "count", relayed)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,25 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+// NewReactionReconciler persists the reaction counters of changed posts to the db
+func NewReactionReconciler(interval time.Duration, lock port.LockRepository, svc port.ReactionService) *Worker {
+	return New("reaction-reconciler", interval, lock, func(ctx context.Context) error {
+		reconciled, err := svc.ReconcileReactionCounts(ctx)
+		if err != nil {
+			return err
+		}
+
+		if reconciled > 0 {
+			slog.Info("reaction counts reconciled", "posts", reconciled)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,25 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+// NewPostScheduler publishes scheduled posts once their publish time has passed
+func NewPostScheduler(interval time.Duration, lock port.LockRepository, svc port.PostService) *Worker {
+	return New("post-scheduler", interval, lock, func(ctx context.Context) error {
+		posts, err := svc.PublishScheduledPosts(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, post := range posts {
+			slog.Info("scheduled post published", "id", post.ID, "slug", post.Slug)
+		}
+
+		return nil
+	})
+}
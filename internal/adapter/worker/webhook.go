@@ -0,0 +1,25 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+// NewWebhookDispatcher sends the due webhook deliveries
+func NewWebhookDispatcher(interval time.Duration, lock port.LockRepository, svc port.WebhookService) *Worker {
+	return New("webhook-dispatcher", interval, lock, func(ctx context.Context) error {
+		attempted, err := svc.DeliverDue(ctx)
+		if err != nil {
+			return err
+		}
+
+		if attempted > 0 {
+			slog.Info("webhook deliveries attempted", "count", attempted)
+		}
+
+		return nil
+	})
+}
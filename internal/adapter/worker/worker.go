@@ -0,0 +1,118 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+// lockTTL is how long the lock outlives a replica that stops renewing it, it's
+// renewed every lockTTL/3 while the job runs so a job may take longer than the
+// interval without another replica starting it
+const lockTTL = 30 * time.Second
+
+type Job func(ctx context.Context) error
+
+// Worker runs a job periodically, guarded by a distributed lock so
+// only one replica runs it at a time
+type Worker struct {
+	name     string
+	interval time.Duration
+	lock     port.LockRepository
+	lockTTL  time.Duration
+	job      Job
+}
+
+func New(name string, interval time.Duration, lock port.LockRepository, job Job) *Worker {
+	return &Worker{
+		name,
+		interval,
+		lock,
+		lockTTL,
+		job,
+	}
+}
+
+// Start blocks until ctx is cancelled
+func (w *Worker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	slog.Info("worker started", "worker", w.name, "interval", w.interval.String())
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("worker stopped", "worker", w.name)
+			return
+		case <-ticker.C:
+			w.run(ctx)
+		}
+	}
+}
+
+func (w *Worker) run(ctx context.Context) {
+	lockKey := "lock:worker:" + w.name
+
+	// skip this tick if another replica holds the lock
+	token, ok, err := w.lock.TryLock(ctx, lockKey, w.lockTTL)
+	if err != nil {
+		slog.Error("unable to acquire worker lock", "worker", w.name, "error", err)
+		return
+	}
+	if !ok {
+		return
+	}
+
+	defer func() {
+		if err := w.lock.Unlock(context.WithoutCancel(ctx), lockKey, token); err != nil {
+			slog.Error("unable to release worker lock", "worker", w.name, "error", err)
+		}
+	}()
+
+	// the job is cancelled once the lock is lost, another replica may take over
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done := make(chan struct{})
+	defer close(done)
+	go w.renewLock(jobCtx, cancel, done, lockKey, token)
+
+	if err := w.job(jobCtx); err != nil {
+		slog.Error("worker job failed", "worker", w.name, "error", err)
+	}
+}
+
+// renewLock extends the lock until the job is done, cancelling the job if the
+// lock can't be kept
+func (w *Worker) renewLock(ctx context.Context, cancel context.CancelFunc, done <-chan struct{}, lockKey, token string) {
+	ticker := time.NewTicker(w.lockTTL / 3)
+	defer ticker.Stop()
+
+	renewedAt := time.Now()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			renewed, err := w.lock.RenewLock(ctx, lockKey, token, w.lockTTL)
+			if err != nil && time.Since(renewedAt) < w.lockTTL {
+				// the lock is still held until it expires, try again next tick
+				slog.Warn("unable to renew worker lock", "worker", w.name, "error", err)
+				continue
+			}
+
+			if err != nil || !renewed {
+				slog.Error("worker lock lost, cancelling job", "worker", w.name, "error", err)
+				cancel()
+				return
+			}
+			renewedAt = time.Now()
+		}
+	}
+}
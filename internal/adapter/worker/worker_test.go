@@ -0,0 +1,87 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeLock is always acquired, held reports whether renewals succeed
+type fakeLock struct {
+	mu       sync.Mutex
+	held     bool
+	renewals int
+	unlocked bool
+}
+
+func (fl *fakeLock) TryLock(ctx context.Context, key string, ttl time.Duration) (string, bool, error) {
+	return "token", true, nil
+}
+
+func (fl *fakeLock) RenewLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	fl.mu.Lock()
+	defer fl.mu.Unlock()
+
+	fl.renewals++
+	return fl.held, nil
+}
+
+func (fl *fakeLock) Unlock(ctx context.Context, key, token string) error {
+	fl.mu.Lock()
+	defer fl.mu.Unlock()
+
+	fl.unlocked = true
+	return nil
+}
+
+func TestWorker_Run(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		held      bool
+		cancelled bool
+	}{
+		{
+			desc: "Success_RenewsLock",
+			held: true,
+		},
+		{
+			desc:      "Fail_LockLost",
+			held:      false,
+			cancelled: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			lock := &fakeLock{held: tc.held}
+
+			// the job runs for several lock ttls unless cancelled
+			var cancelled bool
+			w := New("test", time.Hour, lock, func(ctx context.Context) error {
+				select {
+				case <-ctx.Done():
+					cancelled = true
+				case <-time.After(100 * time.Millisecond):
+				}
+				return nil
+			})
+			w.lockTTL = 30 * time.Millisecond
+
+			w.run(context.Background())
+
+			lock.mu.Lock()
+			defer lock.mu.Unlock()
+
+			assert.Equal(t, tc.cancelled, cancelled)
+			assert.True(t, lock.unlocked)
+			if tc.held {
+				assert.GreaterOrEqual(t, lock.renewals, 3)
+			} else {
+				assert.Equal(t, 1, lock.renewals)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// Bookmark saves a post to the user's reading list
+type Bookmark struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	UserID uint `gorm:"not null;uniqueIndex:idx_bookmarks_user_post" json:"user_id"`
+	PostID uint `gorm:"not null;uniqueIndex:idx_bookmarks_user_post;index" json:"post_id"`
+	Post   Post `gorm:"foreignKey:PostID" json:"post"`
+
+	// Folder optionally groups bookmarks, empty for unfiled bookmarks
+	Folder string `gorm:"type:varchar(100);not null;default:'';index" json:"folder"`
+}
+
+// BookmarkPage is a page of bookmarks, NextCursor is empty on the last page
+type BookmarkPage struct {
+	Bookmarks  []Bookmark `json:"bookmarks"`
+	NextCursor string     `json:"next_cursor"`
+}
+
+type BookmarkFolder struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
@@ -8,3 +8,9 @@ type Category struct {
 	Name        string `gorm:"type:varchar(100);not null;unique" json:"name"`
 	Description string `gorm:"type:text" json:"description"`
 }
+
+// CategoryPatch is a partial update of a category, nil fields are left unchanged
+type CategoryPatch struct {
+	Name        *string
+	Description *string
+}
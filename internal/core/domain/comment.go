@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+type CommentStatus string
+
+const (
+	CommentPending  CommentStatus = "pending"
+	CommentApproved CommentStatus = "approved"
+	CommentRejected CommentStatus = "rejected"
+	CommentSpam     CommentStatus = "spam"
+)
+
+type Comment struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// DeletedAt marks a removed comment, it is kept so its replies stay in the thread
+	DeletedAt *time.Time `json:"deleted_at"`
+
+	PostID uint `gorm:"not null;index" json:"post_id"`
+
+	UserID uint   `gorm:"not null" json:"user_id"`
+	User   Author `gorm:"foreignKey:UserID" json:"user"`
+
+	// RootID is the top level comment of the thread, nil for top level comments
+	ParentID *uint `gorm:"index" json:"parent_id"`
+	RootID   *uint `gorm:"index" json:"root_id"`
+
+	Content string `gorm:"type:text;not null" json:"content"`
+
+	// Status is the moderation state, only approved comments are public
+	Status    CommentStatus `gorm:"type:varchar(20);not null;default:approved;index" json:"status"`
+	SpamScore float64       `gorm:"not null;default:0" json:"spam_score"`
+
+	Replies []Comment `gorm:"-" json:"replies"`
+}
+
+// CommentLiveMessage is sent to the live readers of a post's comments
+type CommentLiveMessage struct {
+	Type   string `json:"type"`
+	PostID uint   `json:"post_id"`
+	UserID uint   `json:"user_id"`
+
+	// Comment is set for created, edited and deleted comments
+	Comment *Comment `json:"comment,omitempty"`
+}
+
+// CommentTyping is the live message type of a user typing a comment
+const CommentTyping = "typing"
@@ -11,4 +11,10 @@ var (
 	ErrConflictingData = errors.New("conflicting data")
 	ErrInvalidIDParam  = errors.New("invalid id parameter")
 	ErrUserNotFound    = errors.New("user is not found")
+	ErrForbidden       = errors.New("forbidden")
+
+	ErrPreconditionFailed   = errors.New("resource has been modified")
+	ErrPreconditionRequired = errors.New("missing If-Match header")
+
+	ErrEditWindowExpired = errors.New("comment can no longer be edited")
 )
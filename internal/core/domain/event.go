@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventCommentCreated    EventType = "comment.created"
+	EventCommentReplied    EventType = "comment.replied"
+	EventCommentEdited     EventType = "comment.edited"
+	EventCommentDeleted    EventType = "comment.deleted"
+	EventPostCreated       EventType = "post.created"
+	EventPostUpdated       EventType = "post.updated"
+	EventPostDeleted       EventType = "post.deleted"
+	EventPostPublished     EventType = "post.published"
+	EventUserCreated       EventType = "user.created"
+	EventUserUpdated       EventType = "user.updated"
+	EventUserDeleted       EventType = "user.deleted"
+	EventUserFollowed      EventType = "user.followed"
+	EventTrustLevelChanged EventType = "user.trust_level_changed"
+	EventCategoryCreated   EventType = "category.created"
+	EventCategoryUpdated   EventType = "category.updated"
+	EventCategoryDeleted   EventType = "category.deleted"
+)
+
+// Event is something that happened in the core, published to interested subscribers
+type Event struct {
+	// ID deduplicates events, they are delivered at least once
+	ID string `json:"id"`
+
+	Type       EventType `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+
+	// ActorID is the user causing the event, 0 for the system
+	ActorID uint `json:"actor_id"`
+
+	// UserID is the user the event is about, e.g. the followed user or the owner of the content
+	UserID uint `json:"user_id"`
+
+	PostID     uint `json:"post_id,omitempty"`
+	CommentID  uint `json:"comment_id,omitempty"`
+	CategoryID uint `json:"category_id,omitempty"`
+}
+
+func NewEvent(eventType EventType, actorID, userID uint) Event {
+	return Event{
+		ID:         newEventID(),
+		Type:       eventType,
+		OccurredAt: time.Now(),
+		ActorID:    actorID,
+		UserID:     userID,
+	}
+}
+
+func newEventID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		// crypto/rand doesn't fail on supported platforms
+		panic(err)
+	}
+
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// Follow is an edge of the follow graph, FollowerID follows FolloweeID
+type Follow struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	FollowerID uint `gorm:"primaryKey" json:"follower_id"`
+	FolloweeID uint `gorm:"primaryKey;index" json:"followee_id"`
+}
+
+// FollowCounts are the number of followers and followed users of a user
+type FollowCounts struct {
+	Followers int64 `json:"followers"`
+	Following int64 `json:"following"`
+}
+
+// FeedEntry is a post in a user's feed, ordered by publish time
+type FeedEntry struct {
+	PostID      uint
+	PublishedAt time.Time
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+type Notification struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// UserID is the recipient
+	UserID uint `gorm:"not null;index:idx_notifications_user_read" json:"user_id"`
+
+	Type    EventType `gorm:"type:varchar(50);not null" json:"type"`
+	ActorID uint      `json:"actor_id"`
+	Message string    `gorm:"type:varchar(255);not null" json:"message"`
+
+	PostID    uint `json:"post_id,omitempty"`
+	CommentID uint `json:"comment_id,omitempty"`
+
+	// ReadAt is nil while the notification is unread
+	ReadAt *time.Time `gorm:"index:idx_notifications_user_read" json:"read_at"`
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// OutboxMessage is an event stored in the transaction of the change causing it,
+// the relay publishes it afterwards so committed changes never lose their events
+type OutboxMessage struct {
+	ID        uint      `gorm:"primarykey"`
+	CreatedAt time.Time `gorm:"index"`
+
+	EventID   string    `gorm:"type:varchar(32);uniqueIndex;not null"`
+	EventType EventType `gorm:"type:varchar(50);not null"`
+	Payload   string    `gorm:"type:text;not null"`
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Post struct {
 	gorm.Model
@@ -12,8 +16,37 @@ type Post struct {
 	Content   string `gorm:"type:text;not null" json:"content"`
 	Published bool   `gorm:"default:false" json:"published"`
 
-	UserID uint `gorm:"not null" json:"user_id"`
-	User   User `gorm:"foreignKey:UserID" json:"user"`
+	// PublishAt schedules an unpublished post to be published by the scheduler
+	PublishAt *time.Time `gorm:"index" json:"publish_at"`
+
+	// PublishedAt is when the post was last published, nil while unpublished
+	PublishedAt *time.Time `gorm:"index" json:"published_at"`
+
+	UserID uint   `gorm:"not null" json:"user_id"`
+	User   Author `gorm:"foreignKey:UserID" json:"user"`
+
+	Tags []Tag `gorm:"many2many:post_tags" json:"tags"`
 
 	Slug string `gorm:"type:varchar(255);not null;unique" json:"slug"`
+
+	// Version is incremented on every update for optimistic concurrency control
+	Version uint `gorm:"not null;default:1" json:"version"`
+
+	// Reactions are read from the reaction counters, not stored with the post
+	Reactions ReactionCounts `gorm:"-" json:"reactions"`
+}
+
+// PostPatch is a partial update of a post, nil fields are left unchanged
+type PostPatch struct {
+	CategoryID *uint
+	Title      *string
+	Content    *string
+	Published  *bool
+
+	// Tags are tag names, replacing all tags of the post
+	Tags *[]string
+
+	// PublishAt reschedules the post, ClearPublishAt removes the schedule
+	PublishAt      *time.Time
+	ClearPublishAt bool
 }
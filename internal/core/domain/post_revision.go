@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// PostRevision is an immutable snapshot of a post's title and content
+type PostRevision struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	PostID uint `gorm:"not null;index" json:"post_id"`
+	UserID uint `gorm:"not null" json:"user_id"`
+
+	Title   string `gorm:"type:varchar(255);not null" json:"title"`
+	Content string `gorm:"type:text;not null" json:"content"`
+}
+
+type PostRevisionDiff struct {
+	From uint   `json:"from"`
+	To   uint   `json:"to"`
+	Diff string `json:"diff"`
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// PostSlug is a previous slug of a post, kept to redirect old urls
+type PostSlug struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	PostID uint   `gorm:"not null;index" json:"post_id"`
+	Slug   string `gorm:"type:varchar(255);not null;unique" json:"slug"`
+}
@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+type ReactionType string
+
+const (
+	ReactionLike  ReactionType = "like"
+	ReactionLove  ReactionType = "love"
+	ReactionLaugh ReactionType = "laugh"
+	ReactionWow   ReactionType = "wow"
+	ReactionSad   ReactionType = "sad"
+	ReactionAngry ReactionType = "angry"
+)
+
+// ReactionTypes are the supported reaction types
+var ReactionTypes = []ReactionType{
+	ReactionLike,
+	ReactionLove,
+	ReactionLaugh,
+	ReactionWow,
+	ReactionSad,
+	ReactionAngry,
+}
+
+func (rt ReactionType) Valid() bool {
+	for _, t := range ReactionTypes {
+		if rt == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Reaction is a user's reaction to a post, a user has at most one reaction per post
+type Reaction struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	PostID uint `gorm:"not null;uniqueIndex:idx_reactions_post_user" json:"post_id"`
+	UserID uint `gorm:"not null;uniqueIndex:idx_reactions_post_user" json:"user_id"`
+
+	Type ReactionType `gorm:"type:varchar(20);not null" json:"type"`
+}
+
+// PostReactionCount is the persisted number of reactions of one type on a post
+type PostReactionCount struct {
+	PostID uint         `gorm:"primaryKey" json:"post_id"`
+	Type   ReactionType `gorm:"primaryKey;type:varchar(20)" json:"type"`
+	Count  int64        `gorm:"not null;default:0" json:"count"`
+}
+
+// ReactionCounts maps reaction types to their number of reactions
+type ReactionCounts map[ReactionType]int64
+
+// ReactionSummary is the state of a post's reactions after a toggle
+type ReactionSummary struct {
+	PostID uint           `json:"post_id"`
+	Counts ReactionCounts `json:"counts"`
+
+	// Reaction is the user's current reaction, nil if removed
+	Reaction *ReactionType `json:"reaction"`
+}
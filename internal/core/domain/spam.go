@@ -0,0 +1,16 @@
+package domain
+
+// SpamModel holds the naive bayes counts of the spam classifier, the word
+// counts only cover the tokens being classified
+type SpamModel struct {
+	// trained comments of each class
+	SpamCount int64
+	HamCount  int64
+	// trained tokens of each class
+	SpamTotal int64
+	HamTotal  int64
+	// distinct trained tokens
+	Vocabulary int64
+	SpamWords  map[string]int64
+	HamWords   map[string]int64
+}
@@ -0,0 +1,14 @@
+package domain
+
+// StreamMessage is pushed to connected clients in real time
+type StreamMessage struct {
+	// ID increases with every message, clients resume after the last id they received
+	ID uint64 `json:"id"`
+
+	Type string `json:"type"`
+
+	// UserID is the recipient, 0 sends the message to every client
+	UserID uint `json:"user_id"`
+
+	Data []byte `json:"data"`
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "gorm.io/gorm"
+
+type Tag struct {
+	gorm.Model
+
+	Name string `gorm:"type:varchar(50);not null;unique" json:"name"`
+	Slug string `gorm:"type:varchar(50);not null;unique" json:"slug"`
+}
+
+// TagWithCount is a tag with the number of posts using it
+type TagWithCount struct {
+	Tag
+
+	PostCount int64 `json:"post_count"`
+}
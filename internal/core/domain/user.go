@@ -13,16 +13,55 @@ const (
 	UserRole  Role = 2001
 )
 
+// TrustLevel decides how much moderation the user's comments need
+type TrustLevel uint8
+
+const (
+	// comments are held for moderation
+	NewTrust TrustLevel = iota
+	// comments are approved unless they look like spam
+	BasicTrust
+	// comments are always approved
+	TrustedTrust
+)
+
+// User is the user as seen outside the repository, the password hash is
+// only ever read and written by the repository
 type User struct {
 	ID        uint `gorm:"primarykey"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 
-	Email    string `json:"email" gorm:"size:255;not null;unique"`
-	Password string `json:"password" gorm:"size:255;not null"`
-	Name     string `json:"name" gorm:"size:255;not null"`
-	Role     Role   `json:"role" gorm:"default:2001;not null"`
+	Email string `json:"email" gorm:"size:255;not null;unique"`
+	Name  string `json:"name" gorm:"size:255;not null"`
+	Role  Role   `json:"role" gorm:"default:2001;not null"`
+
+	TrustLevel TrustLevel `json:"trust_level" gorm:"default:0;not null"`
+
+	// Version is incremented on every update for optimistic concurrency control
+	Version uint `json:"version" gorm:"default:1;not null"`
+
+	// FollowCounts are read from the follow graph, not stored with the user
+	FollowCounts FollowCounts `json:"follow_counts" gorm:"-"`
+}
+
+// Author is the public view of the user who wrote a post or comment, it's
+// loaded from the users table without any private field
+type Author struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+func (Author) TableName() string {
+	return "users"
+}
+
+// UserPatch is a partial update of a user, nil fields are left unchanged
+type UserPatch struct {
+	Email    *string
+	Name     *string
+	Password *string
 }
 
 type UserRequest struct {
@@ -31,13 +70,36 @@ type UserRequest struct {
 	Name     string `json:"name" binding:"required"`
 }
 
+// UserResponse lists the user fields exposed by the api, new user fields
+// stay private until they're added here
 type UserResponse struct {
-	ID        uint `gorm:"primarykey"`
+	ID        uint
 	CreatedAt time.Time
 	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
 
-	Email string `json:"email"`
-	Name  string `json:"name"`
-	Role  Role   `json:"role"`
+	Email      string     `json:"email"`
+	Name       string     `json:"name"`
+	Role       Role       `json:"role"`
+	TrustLevel TrustLevel `json:"trust_level"`
+	Version    uint       `json:"version"`
+
+	// FollowCounts are only set on single users, not on lists
+	FollowCounts *FollowCounts `json:"follow_counts,omitempty" gorm:"-"`
+}
+
+func NewUserResponse(user *User) *UserResponse {
+	counts := user.FollowCounts
+
+	return &UserResponse{
+		ID:         user.ID,
+		CreatedAt:  user.CreatedAt,
+		UpdatedAt:  user.UpdatedAt,
+		Email:      user.Email,
+		Name:       user.Name,
+		Role:       user.Role,
+		TrustLevel: user.TrustLevel,
+		Version:    user.Version,
+
+		FollowCounts: &counts,
+	}
 }
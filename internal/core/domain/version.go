@@ -0,0 +1,19 @@
+package domain
+
+import "slices"
+
+// IfMatch is the precondition of an update, it matches any current version or
+// one of the listed versions
+type IfMatch struct {
+	Any      bool
+	Versions []uint
+}
+
+// MatchVersion is the precondition of an update based on one version
+func MatchVersion(version uint) IfMatch {
+	return IfMatch{Versions: []uint{version}}
+}
+
+func (m IfMatch) Matches(version uint) bool {
+	return m.Any || slices.Contains(m.Versions, version)
+}
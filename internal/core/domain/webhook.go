@@ -0,0 +1,95 @@
+package domain
+
+import "time"
+
+// WebhookEventTypes are the events webhooks can subscribe to
+var WebhookEventTypes = []EventType{
+	EventPostCreated,
+	EventPostUpdated,
+	EventPostDeleted,
+	EventPostPublished,
+	EventUserCreated,
+	EventUserUpdated,
+	EventUserDeleted,
+	EventCategoryCreated,
+	EventCategoryUpdated,
+	EventCategoryDeleted,
+}
+
+// Webhook sends the subscribed events to an external url
+type Webhook struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	URL string `gorm:"type:varchar(2048);not null" json:"url"`
+
+	// Secret signs the payloads so receivers can verify them, it's only
+	// returned once when the webhook is created
+	Secret string `gorm:"type:varchar(255);not null" json:"-"`
+
+	EventTypes []EventType `gorm:"serializer:json;not null" json:"event_types"`
+	Active     bool        `gorm:"not null;default:true" json:"active"`
+}
+
+// CreatedWebhook is the response to creating a webhook, the only one holding the secret
+type CreatedWebhook struct {
+	*Webhook
+	Secret string `json:"secret"`
+}
+
+func (w *Webhook) Subscribed(eventType EventType) bool {
+	for _, t := range w.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "pending"
+	DeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	DeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is a queued event for a webhook and the log of its attempts
+type WebhookDelivery struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	WebhookID uint     `gorm:"not null;index" json:"webhook_id"`
+	Webhook   *Webhook `gorm:"foreignKey:WebhookID;constraint:OnDelete:CASCADE" json:"-"`
+
+	EventType EventType `gorm:"type:varchar(50);not null" json:"event_type"`
+	Payload   string    `gorm:"type:text;not null" json:"payload"`
+
+	Status   WebhookDeliveryStatus `gorm:"type:varchar(20);not null;default:pending;index:idx_webhook_deliveries_due" json:"status"`
+	Attempts int                   `gorm:"not null;default:0" json:"attempts"`
+
+	// NextAttemptAt is when a pending delivery is sent next
+	NextAttemptAt time.Time `gorm:"not null;index:idx_webhook_deliveries_due" json:"next_attempt_at"`
+
+	// result of the last attempt
+	ResponseStatus int        `json:"response_status"`
+	ResponseBody   string     `gorm:"type:text" json:"response_body"`
+	Error          string     `gorm:"type:text" json:"error"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+}
+
+// WebhookRequest is a signed delivery sent to a webhook url
+type WebhookRequest struct {
+	URL     string
+	Headers map[string]string
+	Body    []byte
+}
+
+// WebhookResponse is the receiver's answer to a delivery
+type WebhookResponse struct {
+	Status int
+	Body   string
+}
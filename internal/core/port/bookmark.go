@@ -0,0 +1,28 @@
+package port
+
+import (
+	"context"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+//go:generate mockery --name=BookmarkRepository --output=../../../mocks --outpkg=mocks
+type BookmarkRepository interface {
+	// SaveBookmark creates the bookmark or moves an existing one to the bookmark's folder
+	SaveBookmark(ctx context.Context, bookmark *domain.Bookmark) (*domain.Bookmark, error)
+	DeleteBookmark(ctx context.Context, userID, postID uint) error
+	// GetBookmarks returns bookmarks newest first with ids below beforeID, 0 starts from the newest,
+	// bookmarks of other users' unpublished posts are left out
+	GetBookmarks(ctx context.Context, userID uint, folder *string, beforeID uint, limit int) ([]domain.Bookmark, error)
+	GetBookmarkFolders(ctx context.Context, userID uint) ([]domain.BookmarkFolder, error)
+	DeletePostBookmarks(ctx context.Context, postID uint) error
+	// DeleteUserBookmarks deletes the user's bookmarks and the bookmarks of the user's posts
+	DeleteUserBookmarks(ctx context.Context, userID uint) error
+}
+
+type BookmarkService interface {
+	AddBookmark(ctx context.Context, userID, postID uint, folder string) (*domain.Bookmark, error)
+	RemoveBookmark(ctx context.Context, userID, postID uint) error
+	GetBookmarks(ctx context.Context, userID uint, folder *string, cursor string, limit int) (*domain.BookmarkPage, error)
+	GetBookmarkFolders(ctx context.Context, userID uint) ([]domain.BookmarkFolder, error)
+}
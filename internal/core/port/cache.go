@@ -10,6 +10,20 @@ type CacheRepository interface {
 	Set(ctx context.Context, key string, val []byte, ttl time.Duration) error
 	Get(ctx context.Context, key string) ([]byte, error)
 	Delete(ctx context.Context, key string) error
+	// SetWithTags stores the key and adds it to the tags, InvalidateTags deletes
+	// every key of the tags at once
+	SetWithTags(ctx context.Context, key string, val []byte, ttl time.Duration, tags ...string) error
+	InvalidateTags(ctx context.Context, tags ...string) error
 	DeleteByPrefix(ctx context.Context, prefix string) error
+	HIncrBy(ctx context.Context, key, field string, incr int64) (int64, error)
+	HGetAll(ctx context.Context, key string) (map[string]string, error)
+	// HGetAllMulti returns the hashes of the keys in one round trip, missing hashes are empty
+	HGetAllMulti(ctx context.Context, keys ...string) ([]map[string]string, error)
+	// HSetNX atomically sets the fields the hash doesn't have yet
+	HSetNX(ctx context.Context, key string, fields map[string]int64) error
+	// HReplace atomically replaces the hash with the fields
+	HReplace(ctx context.Context, key string, fields map[string]int64) error
+	SAdd(ctx context.Context, key string, members ...string) error
+	SPop(ctx context.Context, key string, count int64) ([]string, error)
 	Close() error
 }
@@ -10,6 +10,7 @@ type CategoryRepository interface {
 	CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
 	GetCategories(ctx context.Context) ([]domain.Category, error)
 	GetCategoryByID(ctx context.Context, id uint) (*domain.Category, error)
+	UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
 	DeleteCategory(ctx context.Context, id uint) (*domain.Category, error)
 }
 
@@ -17,5 +18,7 @@ type CategoryService interface {
 	CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
 	GetCategories(ctx context.Context) ([]domain.Category, error)
 	GetCategoryByID(ctx context.Context, id uint) (*domain.Category, error)
+	UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
+	PatchCategory(ctx context.Context, id uint, patch *domain.CategoryPatch) (*domain.Category, error)
 	DeleteCategory(ctx context.Context, id uint) (*domain.Category, error)
 }
@@ -0,0 +1,42 @@
+package port
+
+import (
+	"context"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+//go:generate mockery --name=CommentRepository --output=../../../mocks --outpkg=mocks
+type CommentRepository interface {
+	CreateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error)
+	GetCommentByID(ctx context.Context, id uint) (*domain.Comment, error)
+	// GetRootComments returns the top level comments of a post
+	GetRootComments(ctx context.Context, postID uint, start, end uint64) ([]domain.Comment, error)
+	// GetReplies returns every reply in the threads of the given top level comments
+	GetReplies(ctx context.Context, rootIDs []uint) ([]domain.Comment, error)
+	UpdateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error)
+	GetCommentsByStatus(ctx context.Context, status domain.CommentStatus, start, end uint64) ([]domain.Comment, error)
+	// UpdateCommentsStatus returns the comments whose status changed
+	UpdateCommentsStatus(ctx context.Context, ids []uint, status domain.CommentStatus) ([]domain.Comment, error)
+	CountUserComments(ctx context.Context, userID uint, status domain.CommentStatus) (int64, error)
+	// DeleteUserComments marks the user's comments deleted, returning them
+	DeleteUserComments(ctx context.Context, userID uint) ([]domain.Comment, error)
+}
+
+type CommentService interface {
+	CreateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error)
+	GetCommentTree(ctx context.Context, postID uint, start, end uint64) ([]domain.Comment, error)
+	EditComment(ctx context.Context, userID, id uint, content string) (*domain.Comment, error)
+	DeleteComment(ctx context.Context, userID uint, role domain.Role, id uint) (*domain.Comment, error)
+	GetModerationQueue(ctx context.Context, status domain.CommentStatus, start, end uint64) ([]domain.Comment, error)
+	ModerateComments(ctx context.Context, ids []uint, status domain.CommentStatus) ([]domain.Comment, error)
+}
+
+// LiveCommentService broadcasts comment changes and typing to the live readers of a post
+type LiveCommentService interface {
+	// HandleEvent broadcasts created, edited and deleted comments
+	HandleEvent(ctx context.Context, event domain.Event) error
+	Typing(ctx context.Context, postID, userID uint) error
+	// Subscribe returns the post's live messages until ctx is cancelled
+	Subscribe(ctx context.Context, postID uint) (<-chan domain.CommentLiveMessage, error)
+}
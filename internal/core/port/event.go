@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+type EventHandler func(ctx context.Context, event domain.Event) error
+
+// EventBus delivers domain events to the subscribed handlers
+//
+//go:generate mockery --name=EventBus --output=../../../mocks --outpkg=mocks
+type EventBus interface {
+	Publish(ctx context.Context, events ...domain.Event) error
+	Subscribe(handler EventHandler)
+}
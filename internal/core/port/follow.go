@@ -0,0 +1,44 @@
+package port
+
+import (
+	"context"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+//go:generate mockery --name=FollowRepository --output=../../../mocks --outpkg=mocks
+type FollowRepository interface {
+	CreateFollow(ctx context.Context, follow *domain.Follow) (*domain.Follow, error)
+	DeleteFollow(ctx context.Context, followerID, followeeID uint) error
+	// DeleteUserFollows deletes the follows from and to the user
+	DeleteUserFollows(ctx context.Context, userID uint) error
+	GetFollowerIDs(ctx context.Context, userID uint) ([]uint, error)
+	GetFolloweeIDs(ctx context.Context, userID uint) ([]uint, error)
+	CountFollows(ctx context.Context, userID uint) (*domain.FollowCounts, error)
+	// GetLargeFollowees returns the users followed by userID having more than minFollowers followers
+	GetLargeFollowees(ctx context.Context, userID uint, minFollowers int64) ([]uint, error)
+}
+
+// FeedRepository stores the precomputed feed of each user
+//
+//go:generate mockery --name=FeedRepository --output=../../../mocks --outpkg=mocks
+type FeedRepository interface {
+	// AddToFeeds adds the post to the feeds of the users, trimming each feed to maxLength
+	AddToFeeds(ctx context.Context, userIDs []uint, entry domain.FeedEntry, maxLength int64) error
+	// GetFeed returns the newest entries within the range, ok is false if the feed isn't stored
+	GetFeed(ctx context.Context, userID uint, start, end uint64) (entries []domain.FeedEntry, ok bool, err error)
+	ReplaceFeed(ctx context.Context, userID uint, entries []domain.FeedEntry) error
+	DeleteFeed(ctx context.Context, userID uint) error
+}
+
+type FollowService interface {
+	Follow(ctx context.Context, followerID, followeeID uint) error
+	Unfollow(ctx context.Context, followerID, followeeID uint) error
+	GetFollowCounts(ctx context.Context, userID uint) (*domain.FollowCounts, error)
+}
+
+type FeedService interface {
+	// FanOutPost adds a newly published post to the feeds of the author's followers
+	FanOutPost(ctx context.Context, post *domain.Post) error
+	GetFeed(ctx context.Context, userID uint, start, end uint64) ([]domain.Post, error)
+}
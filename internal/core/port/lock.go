@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+// LockRepository provides distributed locks shared by every running replica
+type LockRepository interface {
+	// TryLock acquires the lock without blocking, returning the token needed to release it
+	TryLock(ctx context.Context, key string, ttl time.Duration) (string, bool, error)
+	// RenewLock extends the lock's ttl, returning false if the lock is no longer held
+	RenewLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error)
+	Unlock(ctx context.Context, key, token string) error
+}
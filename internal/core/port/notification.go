@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+//go:generate mockery --name=NotificationRepository --output=../../../mocks --outpkg=mocks
+type NotificationRepository interface {
+	CreateNotification(ctx context.Context, notification *domain.Notification) (*domain.Notification, error)
+	GetNotifications(ctx context.Context, userID uint, unreadOnly bool, start, end uint64) ([]domain.Notification, error)
+	// MarkRead marks the user's notifications as read, returning the number of updated notifications
+	MarkRead(ctx context.Context, userID uint, ids []uint) (int64, error)
+	MarkAllRead(ctx context.Context, userID uint) (int64, error)
+	CountUnread(ctx context.Context, userID uint) (int64, error)
+}
+
+type NotificationService interface {
+	// HandleEvent creates the notification for a domain event
+	HandleEvent(ctx context.Context, event domain.Event) error
+	GetNotifications(ctx context.Context, userID uint, unreadOnly bool, start, end uint64) ([]domain.Notification, error)
+	MarkRead(ctx context.Context, userID uint, ids []uint) error
+	MarkAllRead(ctx context.Context, userID uint) error
+	GetUnreadCount(ctx context.Context, userID uint) (int64, error)
+}
@@ -0,0 +1,35 @@
+package port
+
+import (
+	"context"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+//go:generate mockery --name=OutboxRepository --output=../../../mocks --outpkg=mocks
+type OutboxRepository interface {
+	SaveEvents(ctx context.Context, events ...domain.Event) error
+	// GetOutboxMessages returns the oldest messages first
+	GetOutboxMessages(ctx context.Context, limit int) ([]domain.OutboxMessage, error)
+	DeleteOutboxMessages(ctx context.Context, ids []uint) error
+}
+
+// EventPublisher delivers relayed events to the subscribers
+//
+//go:generate mockery --name=EventPublisher --output=../../../mocks --outpkg=mocks
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...domain.Event) error
+}
+
+// EventDeduplicator remembers handled events so redelivered ones are skipped,
+// keys are event ids qualified by the handler
+type EventDeduplicator interface {
+	Seen(ctx context.Context, key string) (bool, error)
+	// MarkSeen is called once the event was handled
+	MarkSeen(ctx context.Context, key string) error
+}
+
+type OutboxService interface {
+	// Relay publishes the stored events, returning the number published
+	Relay(ctx context.Context) (int, error)
+}
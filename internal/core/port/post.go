@@ -2,22 +2,37 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
 )
 
+//go:generate mockery --name=PostRepository --output=../../../mocks --outpkg=mocks
 type PostRepository interface {
 	CreatePost(ctx context.Context, post *domain.Post) (*domain.Post, error)
 	GetPosts(ctx context.Context, start, end uint64) ([]domain.Post, error)
 	GetPostByID(ctx context.Context, id uint) (*domain.Post, error)
 	UpdatePost(ctx context.Context, post *domain.Post) (*domain.Post, error)
 	DeletePost(ctx context.Context, id uint) (*domain.Post, error)
+	// DeleteUserPosts returns the deleted posts of the user
+	DeleteUserPosts(ctx context.Context, userID uint) ([]domain.Post, error)
+	PublishDuePosts(ctx context.Context, now time.Time) ([]domain.Post, error)
+	// GetPublishedPostsByIDs returns the published posts in the order of the ids
+	GetPublishedPostsByIDs(ctx context.Context, ids []uint) ([]domain.Post, error)
+	// GetFeedEntries returns the newest published posts of the authors
+	GetFeedEntries(ctx context.Context, authorIDs []uint, limit int) ([]domain.FeedEntry, error)
 }
 
 type PostService interface {
 	CreatePost(ctx context.Context, post *domain.Post) (*domain.Post, error)
 	GetPosts(ctx context.Context, start, end uint64) ([]domain.Post, error)
 	GetPostByID(ctx context.Context, id uint) (*domain.Post, error)
-	UpdatePost(ctx context.Context, post *domain.Post) (*domain.Post, error)
-	DeletePost(ctx context.Context, id uint) (*domain.Post, error)
+	GetPostBySlug(ctx context.Context, slug string) (*domain.Post, error)
+	UpdatePost(ctx context.Context, userID uint, ifMatch domain.IfMatch, post *domain.Post) (*domain.Post, error)
+	PatchPost(ctx context.Context, userID, id uint, ifMatch domain.IfMatch, patch *domain.PostPatch) (*domain.Post, error)
+	DeletePost(ctx context.Context, actorID, id uint) (*domain.Post, error)
+	GetPostRevisions(ctx context.Context, postID uint) ([]domain.PostRevision, error)
+	GetPostRevisionDiff(ctx context.Context, postID, fromID, toID uint) (*domain.PostRevisionDiff, error)
+	RestorePostRevision(ctx context.Context, userID, postID, revisionID uint, ifMatch domain.IfMatch) (*domain.Post, error)
+	PublishScheduledPosts(ctx context.Context) ([]domain.Post, error)
 }
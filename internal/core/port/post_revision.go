@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+//go:generate mockery --name=PostRevisionRepository --output=../../../mocks --outpkg=mocks
+type PostRevisionRepository interface {
+	CreateRevision(ctx context.Context, revision *domain.PostRevision) (*domain.PostRevision, error)
+	GetRevisions(ctx context.Context, postID uint) ([]domain.PostRevision, error)
+	GetRevisionByID(ctx context.Context, postID, id uint) (*domain.PostRevision, error)
+}
@@ -0,0 +1,11 @@
+package port
+
+import "context"
+
+// PubSub delivers messages published on a topic to its current subscribers
+// on every running instance, messages aren't retained
+type PubSub interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+	// Subscribe returns the topic's messages until ctx is cancelled
+	Subscribe(ctx context.Context, topic string) (<-chan []byte, error)
+}
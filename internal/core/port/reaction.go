@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+//go:generate mockery --name=ReactionRepository --output=../../../mocks --outpkg=mocks
+type ReactionRepository interface {
+	GetReaction(ctx context.Context, postID, userID uint) (*domain.Reaction, error)
+	CreateReaction(ctx context.Context, reaction *domain.Reaction) (*domain.Reaction, error)
+	UpdateReaction(ctx context.Context, reaction *domain.Reaction) (*domain.Reaction, error)
+	DeleteReaction(ctx context.Context, id uint) error
+	// CountReactions aggregates the reactions of the posts
+	CountReactions(ctx context.Context, postIDs []uint) ([]domain.PostReactionCount, error)
+	// GetReactionCounts returns the persisted counts of the posts
+	GetReactionCounts(ctx context.Context, postIDs []uint) ([]domain.PostReactionCount, error)
+	// SaveReactionCounts replaces the persisted counts of the posts
+	SaveReactionCounts(ctx context.Context, postIDs []uint, counts []domain.PostReactionCount) error
+}
+
+type ReactionService interface {
+	// ToggleReaction adds the reaction, removes it if it's the user's current one, or changes the type
+	ToggleReaction(ctx context.Context, userID, postID uint, reactionType domain.ReactionType) (*domain.ReactionSummary, error)
+	// GetPostReactions returns the reaction counts of the post, ErrNotFound if it doesn't exist
+	GetPostReactions(ctx context.Context, postID uint) (domain.ReactionCounts, error)
+	GetReactionCounts(ctx context.Context, postIDs ...uint) (map[uint]domain.ReactionCounts, error)
+	// ReconcileReactionCounts recounts the reactions of changed posts, returning the number of posts reconciled
+	ReconcileReactionCounts(ctx context.Context) (int, error)
+}
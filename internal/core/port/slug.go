@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+//go:generate mockery --name=SlugRepository --output=../../../mocks --outpkg=mocks
+type SlugRepository interface {
+	// GetTakenSlugs returns the slugs equal to base or base with a numeric suffix, ignoring the redirects of postID
+	GetTakenSlugs(ctx context.Context, base string, postID uint) ([]string, error)
+	CreateSlugRedirect(ctx context.Context, redirect *domain.PostSlug) (*domain.PostSlug, error)
+	DeleteSlugRedirect(ctx context.Context, postID uint, slug string) error
+	GetPostIDBySlug(ctx context.Context, slug string) (uint, error)
+}
+
+type SlugService interface {
+	GenerateSlug(ctx context.Context, postID uint, currentSlug, title string) (string, error)
+	ChangeSlug(ctx context.Context, postID uint, oldSlug, newSlug string) error
+	ResolveSlug(ctx context.Context, slug string) (uint, error)
+}
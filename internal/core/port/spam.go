@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+// SpamChecker scores comments between 0 (ham) and 1 (spam)
+type SpamChecker interface {
+	Check(ctx context.Context, comment *domain.Comment) (float64, error)
+	// Train learns from a moderation decision
+	Train(ctx context.Context, comment *domain.Comment, spam bool) error
+}
+
+// SpamModelRepository stores the spam classifier's training, shared by every replica
+type SpamModelRepository interface {
+	// TrainSpamModel counts the tokens of a moderated comment
+	TrainSpamModel(ctx context.Context, spam bool, tokens []string) error
+	// GetSpamModel returns the class counts and the word counts of the tokens
+	GetSpamModel(ctx context.Context, tokens []string) (*domain.SpamModel, error)
+}
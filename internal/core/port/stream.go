@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+// Broker fans out stream messages to the subscribers of every running instance
+type Broker interface {
+	// Publish assigns the message id and sends it to all subscribers
+	Publish(ctx context.Context, msg *domain.StreamMessage) error
+	// Subscribe returns the published messages until ctx is cancelled
+	Subscribe(ctx context.Context) (<-chan domain.StreamMessage, error)
+	// Since returns the retained messages published after lastID
+	Since(ctx context.Context, lastID uint64) ([]domain.StreamMessage, error)
+}
+
+type StreamService interface {
+	// Send streams the payload to the user, or to everyone if userID is 0
+	Send(ctx context.Context, userID uint, msgType string, payload any) error
+	// Subscribe streams the user's messages, replaying the ones published after lastID
+	Subscribe(ctx context.Context, userID uint, lastID uint64) (<-chan domain.StreamMessage, error)
+	// HandleEvent streams post events to everyone
+	HandleEvent(ctx context.Context, event domain.Event) error
+}
@@ -0,0 +1,27 @@
+package port
+
+import (
+	"context"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+//go:generate mockery --name=TagRepository --output=../../../mocks --outpkg=mocks
+type TagRepository interface {
+	// GetOrCreateTags returns the tags with the given slugs, creating the missing ones
+	GetOrCreateTags(ctx context.Context, tags []domain.Tag) ([]domain.Tag, error)
+	GetTagsWithCount(ctx context.Context) ([]domain.TagWithCount, error)
+	GetTagBySlug(ctx context.Context, slug string) (*domain.Tag, error)
+	GetPostsByTag(ctx context.Context, tagID uint, start, end uint64) ([]domain.Post, error)
+	ReplacePostTags(ctx context.Context, post *domain.Post, tags []domain.Tag) error
+}
+
+type TagService interface {
+	ResolveTags(ctx context.Context, names []string) ([]domain.Tag, error)
+	// SetPostTags returns the previous and the new tags, their caches are
+	// invalidated by the caller once the change is committed
+	SetPostTags(ctx context.Context, post *domain.Post, names []string) ([]domain.Tag, error)
+	GetTags(ctx context.Context) ([]domain.TagWithCount, error)
+	GetPostsByTag(ctx context.Context, slug string, start, end uint64) ([]domain.Post, error)
+	InvalidateTags(ctx context.Context, tags ...domain.Tag) error
+}
@@ -0,0 +1,9 @@
+package port
+
+import "context"
+
+// Transactor runs fn in a transaction, repositories called with the ctx passed
+// to fn take part in it
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -6,21 +6,31 @@ import (
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
 )
 
+// UserRepository hashes and checks the passwords itself, the hashes are
+// never returned
+//
 //go:generate mockery --name=UserRepository --output=../../../mocks --outpkg=mocks
 type UserRepository interface {
-	CreateUser(ctx context.Context, user *domain.User) (*domain.UserResponse, error)
+	CreateUser(ctx context.Context, user *domain.User, password string) (*domain.UserResponse, error)
 	GetUserByID(ctx context.Context, id uint) (*domain.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
+	// VerifyPassword returns the user if the password matches, domain.ErrUnauthorized otherwise
+	VerifyPassword(ctx context.Context, email, password string) (*domain.User, error)
 	GetUsers(ctx context.Context, start, stop uint64) ([]domain.UserResponse, error)
-	UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error)
+	// UpdateUser keeps the stored password when password is empty
+	UpdateUser(ctx context.Context, user *domain.User, password string) (*domain.User, error)
+	UpdateTrustLevel(ctx context.Context, id uint, level domain.TrustLevel) error
 	DeleteUser(ctx context.Context, id uint) (*domain.User, error)
 }
 
 //go:generate mockery --name=UserService --output=../../../mocks --outpkg=mocks
 type UserService interface {
-	RegisterUser(ctx context.Context, user *domain.User) (*domain.UserResponse, error)
+	RegisterUser(ctx context.Context, user *domain.User, password string) (*domain.UserResponse, error)
 	GetUsers(ctx context.Context, start, stop uint64) ([]domain.UserResponse, error)
 	GetUserByID(ctx context.Context, id uint) (*domain.User, error)
-	UpdateUser(ctx context.Context, id uint, user *domain.User) (*domain.User, error)
-	DeleteUser(ctx context.Context, id uint) (*domain.User, error)
+	UpdateUser(ctx context.Context, id uint, ifMatch domain.IfMatch, user *domain.User, password string) (*domain.User, error)
+	PatchUser(ctx context.Context, id uint, ifMatch domain.IfMatch, patch *domain.UserPatch) (*domain.User, error)
+	SetTrustLevel(ctx context.Context, actorID, id uint, level domain.TrustLevel) error
+	// DeleteUser deletes the user and their content, actorID is the admin deleting them
+	DeleteUser(ctx context.Context, actorID, id uint) (*domain.User, error)
 }
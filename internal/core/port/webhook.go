@@ -0,0 +1,49 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+//go:generate mockery --name=WebhookRepository --output=../../../mocks --outpkg=mocks
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+	GetWebhooks(ctx context.Context) ([]domain.Webhook, error)
+	GetActiveWebhooks(ctx context.Context) ([]domain.Webhook, error)
+	GetWebhookByID(ctx context.Context, id uint) (*domain.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id uint) error
+
+	CreateDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error
+	// ClaimDueDeliveries returns pending deliveries whose next attempt is due,
+	// oldest first, moving their next attempt lease ahead so no other replica
+	// sends them meanwhile
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error)
+	GetDeliveries(ctx context.Context, webhookID uint, start, end uint64) ([]domain.WebhookDelivery, error)
+	GetDeliveryByID(ctx context.Context, id uint) (*domain.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) (*domain.WebhookDelivery, error)
+}
+
+// WebhookSender sends deliveries to the receivers
+type WebhookSender interface {
+	// Send returns an error if the receiver couldn't be reached
+	Send(ctx context.Context, req *domain.WebhookRequest) (*domain.WebhookResponse, error)
+}
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+	GetWebhooks(ctx context.Context) ([]domain.Webhook, error)
+	GetWebhookByID(ctx context.Context, id uint) (*domain.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id uint) error
+	GetDeliveries(ctx context.Context, webhookID uint, start, end uint64) ([]domain.WebhookDelivery, error)
+	// Redeliver queues a new delivery of a previous delivery's payload
+	Redeliver(ctx context.Context, deliveryID uint) (*domain.WebhookDelivery, error)
+
+	// HandleEvent queues deliveries to the webhooks subscribed to the event
+	HandleEvent(ctx context.Context, event domain.Event) error
+	// DeliverDue sends the due deliveries, returning the number of attempts
+	DeliverDue(ctx context.Context) (int, error)
+}
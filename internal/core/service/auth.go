@@ -22,15 +22,11 @@ func NewAuthService(conf *config.JWT, userRepo port.UserRepository) *AuthService
 }
 
 func (as *AuthService) Login(ctx context.Context, email, password string) (string, string, error) {
-	user, err := as.userRepo.GetUserByEmail(ctx, email)
+	user, err := as.userRepo.VerifyPassword(ctx, email, password)
 	if err != nil {
 		return "", "", err
 	}
 
-	if err := util.CompareHashedPwd(user.Password, password); err != nil {
-		return "", "", err
-	}
-
 	// generate jwt token
 	refreshToken, err := util.GenerateJWTToken(as.conf, user, "refresh")
 	if err != nil {
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/util"
+)
+
+const (
+	defaultBookmarkLimit = 20
+	maxBookmarkLimit     = 100
+	maxFolderLength      = 100
+)
+
+type BookmarkService struct {
+	repo     port.BookmarkRepository
+	postRepo port.PostRepository
+}
+
+func NewBookmarkService(repo port.BookmarkRepository, postRepo port.PostRepository) *BookmarkService {
+	return &BookmarkService{
+		repo,
+		postRepo,
+	}
+}
+
+// AddBookmark bookmarks the post, bookmarking it again moves it to the given folder
+func (bs *BookmarkService) AddBookmark(ctx context.Context, userID, postID uint, folder string) (*domain.Bookmark, error) {
+	folder = strings.TrimSpace(folder)
+	if len(folder) > maxFolderLength {
+		return nil, domain.ErrBadRequest
+	}
+
+	// check post exists, other users' drafts are hidden
+	post, err := bs.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !post.Published && post.UserID != userID {
+		return nil, domain.ErrNotFound
+	}
+
+	bookmark, err := bs.repo.SaveBookmark(ctx, &domain.Bookmark{
+		UserID: userID,
+		PostID: postID,
+		Folder: folder,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bookmark.Post = *post
+	return bookmark, nil
+}
+
+func (bs *BookmarkService) RemoveBookmark(ctx context.Context, userID, postID uint) error {
+	return bs.repo.DeleteBookmark(ctx, userID, postID)
+}
+
+// GetBookmarks returns a page of bookmarks newest first, a nil folder returns bookmarks of all folders
+func (bs *BookmarkService) GetBookmarks(ctx context.Context, userID uint, folder *string, cursor string, limit int) (*domain.BookmarkPage, error) {
+	if limit <= 0 {
+		limit = defaultBookmarkLimit
+	}
+	if limit > maxBookmarkLimit {
+		limit = maxBookmarkLimit
+	}
+
+	beforeID, err := util.DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// get one extra bookmark to know whether there's a next page
+	bookmarks, err := bs.repo.GetBookmarks(ctx, userID, folder, beforeID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.BookmarkPage{Bookmarks: bookmarks}
+	if len(bookmarks) > limit {
+		page.Bookmarks = bookmarks[:limit]
+		page.NextCursor = util.EncodeCursor(page.Bookmarks[limit-1].ID)
+	}
+
+	return page, nil
+}
+
+func (bs *BookmarkService) GetBookmarkFolders(ctx context.Context, userID uint) ([]domain.BookmarkFolder, error) {
+	return bs.repo.GetBookmarkFolders(ctx, userID)
+}
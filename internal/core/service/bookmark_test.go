@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/mocks"
+	"gorm.io/gorm"
+)
+
+func TestBookmarkService_AddBookmark(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		desc   string
+		userID uint
+		folder string
+		mocks  func(repo *mocks.BookmarkRepository, postRepo *mocks.PostRepository)
+		err    error
+	}{
+		{
+			desc:   "Success",
+			userID: 2,
+			folder: " Later ",
+			mocks: func(repo *mocks.BookmarkRepository, postRepo *mocks.PostRepository) {
+				postRepo.On("GetPostByID", ctx, uint(1)).Return(&domain.Post{Model: gorm.Model{ID: 1}, UserID: 1, Published: true}, nil)
+				repo.On("SaveBookmark", ctx, &domain.Bookmark{UserID: 2, PostID: 1, Folder: "Later"}).Return(&domain.Bookmark{ID: 1, UserID: 2, PostID: 1, Folder: "Later"}, nil)
+			},
+		},
+		{
+			desc:   "Success_OwnDraft",
+			userID: 1,
+			mocks: func(repo *mocks.BookmarkRepository, postRepo *mocks.PostRepository) {
+				postRepo.On("GetPostByID", ctx, uint(1)).Return(&domain.Post{Model: gorm.Model{ID: 1}, UserID: 1}, nil)
+				repo.On("SaveBookmark", ctx, mock.Anything).Return(&domain.Bookmark{ID: 1, UserID: 1, PostID: 1}, nil)
+			},
+		},
+		{
+			desc:   "Fail_DeletedPost",
+			userID: 2,
+			mocks: func(repo *mocks.BookmarkRepository, postRepo *mocks.PostRepository) {
+				postRepo.On("GetPostByID", ctx, uint(1)).Return(nil, domain.ErrNotFound)
+			},
+			err: domain.ErrNotFound,
+		},
+		{
+			desc:   "Fail_PostRepoError",
+			userID: 2,
+			mocks: func(repo *mocks.BookmarkRepository, postRepo *mocks.PostRepository) {
+				postRepo.On("GetPostByID", ctx, uint(1)).Return(nil, domain.ErrInternal)
+			},
+			err: domain.ErrInternal,
+		},
+		{
+			desc:   "Fail_UnpublishedPost",
+			userID: 2,
+			mocks: func(repo *mocks.BookmarkRepository, postRepo *mocks.PostRepository) {
+				postRepo.On("GetPostByID", ctx, uint(1)).Return(&domain.Post{Model: gorm.Model{ID: 1}, UserID: 1}, nil)
+			},
+			err: domain.ErrNotFound,
+		},
+		{
+			desc:   "Fail_FolderTooLong",
+			userID: 2,
+			folder: strings.Repeat("a", maxFolderLength+1),
+			mocks:  func(repo *mocks.BookmarkRepository, postRepo *mocks.PostRepository) {},
+			err:    domain.ErrBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := mocks.NewBookmarkRepository(t)
+			postRepo := mocks.NewPostRepository(t)
+			tc.mocks(repo, postRepo)
+
+			bookmarkService := NewBookmarkService(repo, postRepo)
+
+			bookmark, err := bookmarkService.AddBookmark(ctx, tc.userID, 1, tc.folder)
+			assert.Equal(t, tc.err, err)
+			if tc.err != nil {
+				return
+			}
+
+			assert.Equal(t, uint(1), bookmark.Post.ID)
+		})
+	}
+}
+
+func TestBookmarkService_RemoveBookmark(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		desc string
+		err  error
+	}{
+		{
+			// bookmarks of deleted or unpublished posts are removed without looking the post up
+			desc: "Success",
+		},
+		{
+			desc: "Fail_NotFound",
+			err:  domain.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := mocks.NewBookmarkRepository(t)
+			repo.On("DeleteBookmark", ctx, uint(2), uint(1)).Return(tc.err)
+
+			bookmarkService := NewBookmarkService(repo, mocks.NewPostRepository(t))
+
+			err := bookmarkService.RemoveBookmark(ctx, 2, 1)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
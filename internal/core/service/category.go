@@ -8,35 +8,49 @@ import (
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/util"
 )
 
+// categories are cached as a single list
+const allCategories = "all"
+
 type CategoryService struct {
-	repo  port.CategoryRepository
-	cache port.CacheRepository
+	repo            port.CategoryRepository
+	tx              port.Transactor
+	events          port.EventBus
+	cache           port.CacheRepository
+	categoryCache   *util.CacheAside[domain.Category]
+	categoriesCache *util.CacheAside[[]domain.Category]
 }
 
-func NewCategoryService(repo port.CategoryRepository, cache port.CacheRepository) *CategoryService {
+func NewCategoryService(repo port.CategoryRepository, tx port.Transactor, events port.EventBus, cache port.CacheRepository, policy *util.CachePolicy) *CategoryService {
 	return &CategoryService{
 		repo,
+		tx,
+		events,
 		cache,
+		util.NewCacheAside(cache, "category", policy, util.EntityCacheTags[domain.Category]("category")),
+		util.NewCacheAside(cache, "categories", policy, util.ListCacheTags("categories", "category", categoryCacheID)),
 	}
 }
 
 func (cs *CategoryService) CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
-	category, err := cs.repo.CreateCategory(ctx, category)
-	if err != nil {
-		return nil, err
-	}
+	// create category, its event is stored in the same transaction
+	err := cs.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if category, err = cs.repo.CreateCategory(ctx, category); err != nil {
+			return err
+		}
 
-	cacheKey := util.GenerateCacheKey("category", category.ID)
-	categorySerialized, err := util.Serialize(category)
+		return cs.publishCategoryEvent(ctx, domain.EventCategoryCreated, category.ID)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err := cs.cache.Set(ctx, cacheKey, categorySerialized, 0); err != nil {
+	// clear categories cache
+	if err := cs.cache.InvalidateTags(ctx, util.ListCacheTag("categories")); err != nil {
 		return nil, err
 	}
 
-	if err := cs.cache.Delete(ctx, "categories"); err != nil {
+	if err := cs.categoryCache.Set(ctx, category.ID, *category); err != nil {
 		return nil, err
 	}
 
@@ -44,57 +58,83 @@ func (cs *CategoryService) CreateCategory(ctx context.Context, category *domain.
 }
 
 func (cs *CategoryService) GetCategories(ctx context.Context) ([]domain.Category, error) {
-	var categories []domain.Category
-	cacheKey := "categories"
+	return cs.categoriesCache.Get(ctx, allCategories, cs.repo.GetCategories)
+}
 
-	categoriesSerialized, err := cs.cache.Get(ctx, cacheKey)
-	if err == nil {
-		if err := util.Deserialize(categoriesSerialized, &categories); err != nil {
-			return nil, err
+func (cs *CategoryService) GetCategoryByID(ctx context.Context, id uint) (*domain.Category, error) {
+	category, err := cs.categoryCache.Get(ctx, id, func(ctx context.Context) (domain.Category, error) {
+		category, err := cs.repo.GetCategoryByID(ctx, id)
+		if err != nil {
+			return domain.Category{}, err
 		}
-		return categories, nil
-	}
 
-	categories, err = cs.repo.GetCategories(ctx)
+		return *category, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	categoriesSerialized, err = util.Serialize(categories)
+	return &category, nil
+}
+
+// UpdateCategory replaces all editable fields of a category
+func (cs *CategoryService) UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	foundCategory, err := cs.repo.GetCategoryByID(ctx, category.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := cs.cache.Set(ctx, cacheKey, categoriesSerialized, 0); err != nil {
+	// replace category
+	foundCategory.Name = category.Name
+	foundCategory.Description = category.Description
+
+	return cs.saveCategory(ctx, foundCategory)
+}
+
+// PatchCategory only updates the fields set in the patch
+func (cs *CategoryService) PatchCategory(ctx context.Context, id uint, patch *domain.CategoryPatch) (*domain.Category, error) {
+	foundCategory, err := cs.repo.GetCategoryByID(ctx, id)
+	if err != nil {
 		return nil, err
 	}
 
-	return categories, nil
-}
+	// patch category
+	if patch.Name != nil {
+		foundCategory.Name = *patch.Name
+	}
+	if patch.Description != nil {
+		foundCategory.Description = *patch.Description
+	}
 
-func (cs *CategoryService) GetCategoryByID(ctx context.Context, id uint) (*domain.Category, error) {
-	category := &domain.Category{}
-	cacheKey := util.GenerateCacheKey("category", id)
+	// required fields can't be cleared
+	if foundCategory.Name == "" {
+		return nil, domain.ErrBadRequest
+	}
+
+	return cs.saveCategory(ctx, foundCategory)
+}
 
-	categorySerialized, err := cs.cache.Get(ctx, cacheKey)
-	if err == nil {
-		if err := util.Deserialize(categorySerialized, category); err != nil {
-			return nil, err
+func (cs *CategoryService) saveCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	// update category, its event is stored in the same transaction
+	err := cs.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if category, err = cs.repo.UpdateCategory(ctx, category); err != nil {
+			return err
 		}
-		return category, nil
-	}
 
-	category, err = cs.repo.GetCategoryByID(ctx, id)
+		return cs.publishCategoryEvent(ctx, domain.EventCategoryUpdated, category.ID)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	categorySerialized, err = util.Serialize(category)
-	if err != nil {
+	// clear the category and the lists containing it
+	if err := cs.cache.InvalidateTags(ctx, util.CacheTag("category", category.ID)); err != nil {
 		return nil, err
 	}
 
-	if err := cs.cache.Set(ctx, cacheKey, categorySerialized, 0); err != nil {
+	// set category cache
+	if err := cs.categoryCache.Set(ctx, category.ID, *category); err != nil {
 		return nil, err
 	}
 
@@ -102,15 +142,35 @@ func (cs *CategoryService) GetCategoryByID(ctx context.Context, id uint) (*domai
 }
 
 func (cs *CategoryService) DeleteCategory(ctx context.Context, id uint) (*domain.Category, error) {
-	cacheKey := util.GenerateCacheKey("category", id)
-
-	if err := cs.cache.Delete(ctx, cacheKey); err != nil {
+	// clear the category and the lists containing it
+	if err := cs.cache.InvalidateTags(ctx, util.CacheTag("category", id)); err != nil {
 		return nil, err
 	}
 
-	if err := cs.cache.Delete(ctx, "categories"); err != nil {
+	// delete category, its event is stored in the same transaction
+	var category *domain.Category
+	err := cs.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if category, err = cs.repo.DeleteCategory(ctx, id); err != nil {
+			return err
+		}
+
+		return cs.publishCategoryEvent(ctx, domain.EventCategoryDeleted, id)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return cs.repo.DeleteCategory(ctx, id)
+	return category, nil
+}
+
+func (cs *CategoryService) publishCategoryEvent(ctx context.Context, eventType domain.EventType, id uint) error {
+	event := domain.NewEvent(eventType, 0, 0)
+	event.CategoryID = id
+
+	return cs.events.Publish(ctx, event)
+}
+
+func categoryCacheID(category domain.Category) any {
+	return category.ID
 }
@@ -0,0 +1,449 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/util"
+)
+
+const (
+	// comments scoring at least spamThreshold are marked as spam
+	spamThreshold = 0.9
+	// comments of basic trust users scoring at least holdThreshold are held for moderation
+	holdThreshold = 0.5
+)
+
+type CommentService struct {
+	// editWindow is how long authors may edit their comments
+	editWindow time.Duration
+	// autoTrustAfter is the approved comments count promoting new users to basic trust
+	autoTrustAfter int
+	repo           port.CommentRepository
+	postRepo       port.PostRepository
+	userRepo       port.UserRepository
+	spam           port.SpamChecker
+	tx             port.Transactor
+	events         port.EventBus
+	cache          port.CacheRepository
+	treeCache      *util.CacheAside[[]domain.Comment]
+}
+
+func NewCommentService(editWindow time.Duration, autoTrustAfter int, repo port.CommentRepository, postRepo port.PostRepository, userRepo port.UserRepository, spam port.SpamChecker, tx port.Transactor, events port.EventBus, cache port.CacheRepository, policy *util.CachePolicy) *CommentService {
+	return &CommentService{
+		editWindow,
+		autoTrustAfter,
+		repo,
+		postRepo,
+		userRepo,
+		spam,
+		tx,
+		events,
+		cache,
+		util.NewCacheAside(cache, "comments", policy, commentTreeCacheTags),
+	}
+}
+
+func (cs *CommentService) CreateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+	comment.Content = strings.TrimSpace(comment.Content)
+	if comment.Content == "" {
+		return nil, domain.ErrBadRequest
+	}
+
+	// check post exists, other users' drafts are hidden
+	post, err := cs.postRepo.GetPostByID(ctx, comment.PostID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !post.Published && post.UserID != comment.UserID {
+		return nil, domain.ErrNotFound
+	}
+
+	// replies join the thread of their parent
+	if comment.ParentID != nil {
+		parent, err := cs.repo.GetCommentByID(ctx, *comment.ParentID)
+		if err != nil {
+			return nil, err
+		}
+
+		if parent.PostID != comment.PostID || parent.DeletedAt != nil || parent.Status != domain.CommentApproved {
+			return nil, domain.ErrBadRequest
+		}
+
+		rootID := parent.ID
+		if parent.RootID != nil {
+			rootID = *parent.RootID
+		}
+		comment.RootID = &rootID
+	}
+
+	if err := cs.moderate(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	// create comment, its events are stored in the same transaction, pending
+	// comments notify once approved
+	err = cs.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if comment, err = cs.repo.CreateComment(ctx, comment); err != nil {
+			return err
+		}
+
+		if comment.Status != domain.CommentApproved {
+			return nil
+		}
+
+		return cs.publishCommentCreated(ctx, comment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// clear comments cache, pending comments do so once approved
+	if comment.Status == domain.CommentApproved {
+		if err := cs.clearCommentsCache(ctx, comment.PostID); err != nil {
+			return nil, err
+		}
+	}
+
+	return comment, nil
+}
+
+// publishCommentCreated notifies the post author, and the parent comment author of a reply
+func (cs *CommentService) publishCommentCreated(ctx context.Context, comment *domain.Comment) error {
+	post, err := cs.postRepo.GetPostByID(ctx, comment.PostID)
+	if err != nil {
+		return err
+	}
+
+	event := domain.NewEvent(domain.EventCommentCreated, comment.UserID, post.UserID)
+	event.PostID = comment.PostID
+	event.CommentID = comment.ID
+	events := []domain.Event{event}
+
+	if comment.ParentID != nil {
+		parent, err := cs.repo.GetCommentByID(ctx, *comment.ParentID)
+		if err != nil {
+			return err
+		}
+
+		// the post author is already notified
+		if parent.UserID != post.UserID {
+			reply := domain.NewEvent(domain.EventCommentReplied, comment.UserID, parent.UserID)
+			reply.PostID = comment.PostID
+			reply.CommentID = comment.ID
+			events = append(events, reply)
+		}
+	}
+
+	return cs.events.Publish(ctx, events...)
+}
+
+// moderate sets the status of a new or edited comment from the author's trust level and spam score
+func (cs *CommentService) moderate(ctx context.Context, comment *domain.Comment) error {
+	user, err := cs.userRepo.GetUserByID(ctx, comment.UserID)
+	if err != nil {
+		return err
+	}
+
+	// trusted users skip the spam check
+	if user.TrustLevel >= domain.TrustedTrust {
+		comment.Status = domain.CommentApproved
+		comment.SpamScore = 0
+		return nil
+	}
+
+	score, err := cs.spam.Check(ctx, comment)
+	if err != nil {
+		return err
+	}
+	comment.SpamScore = score
+
+	switch {
+	case score >= spamThreshold:
+		comment.Status = domain.CommentSpam
+	case user.TrustLevel == domain.BasicTrust && score < holdThreshold:
+		comment.Status = domain.CommentApproved
+	default:
+		comment.Status = domain.CommentPending
+	}
+
+	return nil
+}
+
+// GetModerationQueue returns the comments with the given status, oldest first
+func (cs *CommentService) GetModerationQueue(ctx context.Context, status domain.CommentStatus, start, end uint64) ([]domain.Comment, error) {
+	if !validCommentStatus(status) {
+		return nil, domain.ErrBadRequest
+	}
+
+	return cs.repo.GetCommentsByStatus(ctx, status, start, end)
+}
+
+// ModerateComments sets the status of the comments and trains the spam checker with the decision
+func (cs *CommentService) ModerateComments(ctx context.Context, ids []uint, status domain.CommentStatus) ([]domain.Comment, error) {
+	if len(ids) == 0 || !validCommentStatus(status) || status == domain.CommentPending {
+		return nil, domain.ErrBadRequest
+	}
+
+	// update the comments, the events of approved ones are stored in the same
+	// transaction
+	var comments []domain.Comment
+	err := cs.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if comments, err = cs.repo.UpdateCommentsStatus(ctx, ids, status); err != nil {
+			return err
+		}
+
+		if status != domain.CommentApproved {
+			return nil
+		}
+
+		for i := range comments {
+			if err := cs.publishCommentCreated(ctx, &comments[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	posts := map[uint]struct{}{}
+	users := map[uint]struct{}{}
+	for i := range comments {
+		posts[comments[i].PostID] = struct{}{}
+
+		// rejected comments are not necessarily spam, so they aren't used for training,
+		// a failed training doesn't undo the decision
+		if status != domain.CommentRejected {
+			if err := cs.spam.Train(ctx, &comments[i], status == domain.CommentSpam); err != nil {
+				slog.Warn("unable to train spam checker", "comment_id", comments[i].ID, "error", err)
+			}
+		}
+
+		if status == domain.CommentApproved {
+			users[comments[i].UserID] = struct{}{}
+		}
+	}
+
+	// promote new users once enough of their comments were approved
+	for userID := range users {
+		if err := cs.promoteUser(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	// clear comments cache
+	for postID := range posts {
+		if err := cs.clearCommentsCache(ctx, postID); err != nil {
+			return nil, err
+		}
+	}
+
+	return comments, nil
+}
+
+func (cs *CommentService) promoteUser(ctx context.Context, userID uint) error {
+	user, err := cs.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.TrustLevel != domain.NewTrust {
+		return nil
+	}
+
+	approved, err := cs.repo.CountUserComments(ctx, userID, domain.CommentApproved)
+	if err != nil {
+		return err
+	}
+
+	if approved < int64(cs.autoTrustAfter) {
+		return nil
+	}
+
+	if err := cs.userRepo.UpdateTrustLevel(ctx, userID, domain.BasicTrust); err != nil {
+		return err
+	}
+
+	return cs.cache.InvalidateTags(ctx, util.CacheTag("user", userID))
+}
+
+func validCommentStatus(status domain.CommentStatus) bool {
+	switch status {
+	case domain.CommentPending, domain.CommentApproved, domain.CommentRejected, domain.CommentSpam:
+		return true
+	}
+
+	return false
+}
+
+// GetCommentTree returns a page of top level comments with their nested replies
+func (cs *CommentService) GetCommentTree(ctx context.Context, postID uint, start, end uint64) ([]domain.Comment, error) {
+	param := commentTreeParam{postID, start, end}
+	return cs.treeCache.Get(ctx, param, func(ctx context.Context) ([]domain.Comment, error) {
+		roots, err := cs.repo.GetRootComments(ctx, postID, start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		rootIDs := make([]uint, len(roots))
+		for i, root := range roots {
+			rootIDs[i] = root.ID
+		}
+
+		replies, err := cs.repo.GetReplies(ctx, rootIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildCommentTree(roots, replies), nil
+	})
+}
+
+// EditComment lets the author change the content within the edit window
+func (cs *CommentService) EditComment(ctx context.Context, userID, id uint, content string) (*domain.Comment, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, domain.ErrBadRequest
+	}
+
+	comment, err := cs.repo.GetCommentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.UserID != userID || comment.DeletedAt != nil {
+		return nil, domain.ErrForbidden
+	}
+
+	if time.Since(comment.CreatedAt) > cs.editWindow {
+		return nil, domain.ErrEditWindowExpired
+	}
+
+	// the new content is moderated again, an edit can take a comment out of
+	// the thread but never approves one awaiting moderation
+	status := comment.Status
+	comment.Content = content
+	if err := cs.moderate(ctx, comment); err != nil {
+		return nil, err
+	}
+	if status != domain.CommentApproved && comment.Status == domain.CommentApproved {
+		comment.Status = status
+	}
+
+	if comment, err = cs.updateComment(ctx, domain.EventCommentEdited, userID, comment); err != nil {
+		return nil, err
+	}
+
+	// clear comments cache
+	if err := cs.clearCommentsCache(ctx, comment.PostID); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// DeleteComment blanks the comment but keeps it so the thread structure is preserved
+func (cs *CommentService) DeleteComment(ctx context.Context, userID uint, role domain.Role, id uint) (*domain.Comment, error) {
+	comment, err := cs.repo.GetCommentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.UserID != userID && role != domain.AdminRole {
+		return nil, domain.ErrForbidden
+	}
+
+	if comment.DeletedAt != nil {
+		return comment, nil
+	}
+
+	now := time.Now()
+	comment.DeletedAt = &now
+	comment.Content = ""
+
+	if comment, err = cs.updateComment(ctx, domain.EventCommentDeleted, userID, comment); err != nil {
+		return nil, err
+	}
+
+	// clear comments cache
+	if err := cs.clearCommentsCache(ctx, comment.PostID); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// updateComment stores the comment, its event is stored in the same transaction
+func (cs *CommentService) updateComment(ctx context.Context, eventType domain.EventType, actorID uint, comment *domain.Comment) (*domain.Comment, error) {
+	var updated *domain.Comment
+	err := cs.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if updated, err = cs.repo.UpdateComment(ctx, comment); err != nil {
+			return err
+		}
+
+		event := domain.NewEvent(eventType, actorID, updated.UserID)
+		event.PostID = updated.PostID
+		event.CommentID = updated.ID
+
+		return cs.events.Publish(ctx, event)
+	})
+
+	return updated, err
+}
+
+func (cs *CommentService) clearCommentsCache(ctx context.Context, postID uint) error {
+	return cs.cache.InvalidateTags(ctx, util.CacheTag("comments", postID))
+}
+
+// commentTreeParam identifies a cached page of a post's comment tree
+type commentTreeParam struct {
+	postID     uint
+	start, end uint64
+}
+
+func (p commentTreeParam) String() string {
+	return util.GenerateCacheKeyParams(p.postID, p.start, p.end)
+}
+
+// commentTreeCacheTags tags the pages with their post, so any comment change of
+// the post invalidates them
+func commentTreeCacheTags(param any, _ []domain.Comment) []string {
+	return []string{util.CacheTag("comments", param.(commentTreeParam).postID)}
+}
+
+// nests replies under their parents, replies are ordered oldest first
+func buildCommentTree(roots, replies []domain.Comment) []domain.Comment {
+	children := map[uint][]domain.Comment{}
+	for _, reply := range replies {
+		children[*reply.ParentID] = append(children[*reply.ParentID], reply)
+	}
+
+	var attach func(comment *domain.Comment)
+	attach = func(comment *domain.Comment) {
+		comment.Replies = children[comment.ID]
+		if comment.Replies == nil {
+			comment.Replies = []domain.Comment{}
+		}
+
+		for i := range comment.Replies {
+			attach(&comment.Replies[i])
+		}
+	}
+
+	for i := range roots {
+		attach(&roots[i])
+	}
+
+	return roots
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/util"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/mocks"
+)
+
+// fixedSpamChecker scores every comment the same
+type fixedSpamChecker float64
+
+func (sc fixedSpamChecker) Check(ctx context.Context, comment *domain.Comment) (float64, error) {
+	return float64(sc), nil
+}
+
+func (sc fixedSpamChecker) Train(ctx context.Context, comment *domain.Comment, spam bool) error {
+	return nil
+}
+
+func TestCommentService_CreateComment(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		desc  string
+		post  *domain.Post
+		mocks func(repo *mocks.CommentRepository, userRepo *mocks.UserRepository, cache *mocks.CacheRepository)
+		err   error
+	}{
+		{
+			desc: "Success",
+			post: &domain.Post{UserID: 4, Published: true},
+			mocks: func(repo *mocks.CommentRepository, userRepo *mocks.UserRepository, cache *mocks.CacheRepository) {
+				userRepo.On("GetUserByID", ctx, uint(3)).Return(&domain.User{ID: 3, TrustLevel: domain.TrustedTrust}, nil)
+				repo.On("CreateComment", ctx, mock.Anything).Return(func(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+					return comment, nil
+				})
+				cache.On("InvalidateTags", ctx, util.CacheTag("comments", 2)).Return(nil)
+			},
+		},
+		{
+			desc: "Success_OwnDraft",
+			post: &domain.Post{UserID: 3, Published: false},
+			mocks: func(repo *mocks.CommentRepository, userRepo *mocks.UserRepository, cache *mocks.CacheRepository) {
+				userRepo.On("GetUserByID", ctx, uint(3)).Return(&domain.User{ID: 3, TrustLevel: domain.TrustedTrust}, nil)
+				repo.On("CreateComment", ctx, mock.Anything).Return(func(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+					return comment, nil
+				})
+				cache.On("InvalidateTags", ctx, util.CacheTag("comments", 2)).Return(nil)
+			},
+		},
+		{
+			desc:  "Fail_UnpublishedPost",
+			post:  &domain.Post{UserID: 4, Published: false},
+			mocks: func(repo *mocks.CommentRepository, userRepo *mocks.UserRepository, cache *mocks.CacheRepository) {},
+			err:   domain.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			postRepo := mocks.NewPostRepository(t)
+			postRepo.On("GetPostByID", ctx, uint(2)).Return(tc.post, nil)
+
+			repo := mocks.NewCommentRepository(t)
+			userRepo := mocks.NewUserRepository(t)
+			cache := mocks.NewCacheRepository(t)
+			tc.mocks(repo, userRepo, cache)
+
+			commentService := NewCommentService(time.Hour, 3, repo, postRepo, userRepo, fixedSpamChecker(0), fakeTransactor{}, newEventBusMock(), cache, nil)
+
+			comment, err := commentService.CreateComment(ctx, &domain.Comment{PostID: 2, UserID: 3, Content: "Hello"})
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				assert.Equal(t, domain.CommentApproved, comment.Status)
+			}
+		})
+	}
+}
+
+func TestCommentService_EditComment(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		desc       string
+		trustLevel domain.TrustLevel
+		status     domain.CommentStatus
+		score      float64
+		expected   domain.CommentStatus
+	}{
+		{
+			desc:       "approved comment edited into spam",
+			trustLevel: domain.BasicTrust,
+			status:     domain.CommentApproved,
+			score:      0.95,
+			expected:   domain.CommentSpam,
+		},
+		{
+			desc:       "approved comment of a new user is queued again",
+			trustLevel: domain.NewTrust,
+			status:     domain.CommentApproved,
+			score:      0.1,
+			expected:   domain.CommentPending,
+		},
+		{
+			desc:       "pending comment isn't approved by an edit",
+			trustLevel: domain.BasicTrust,
+			status:     domain.CommentPending,
+			score:      0.1,
+			expected:   domain.CommentPending,
+		},
+		{
+			desc:       "trusted users skip the spam check",
+			trustLevel: domain.TrustedTrust,
+			status:     domain.CommentApproved,
+			score:      0.95,
+			expected:   domain.CommentApproved,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			comment := &domain.Comment{
+				ID:        1,
+				CreatedAt: time.Now(),
+				PostID:    2,
+				UserID:    3,
+				Content:   "first draft",
+				Status:    tc.status,
+			}
+
+			repo := mocks.NewCommentRepository(t)
+			repo.On("GetCommentByID", ctx, comment.ID).Return(comment, nil)
+			repo.On("UpdateComment", ctx, mock.Anything).Return(func(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+				return comment, nil
+			})
+
+			userRepo := mocks.NewUserRepository(t)
+			userRepo.On("GetUserByID", ctx, comment.UserID).Return(&domain.User{ID: comment.UserID, TrustLevel: tc.trustLevel}, nil)
+
+			cache := mocks.NewCacheRepository(t)
+			cache.On("InvalidateTags", ctx, mock.Anything).Return(nil)
+
+			commentService := NewCommentService(time.Hour, 3, repo, nil, userRepo, fixedSpamChecker(tc.score), fakeTransactor{}, newEventBusMock(), cache, nil)
+
+			edited, err := commentService.EditComment(ctx, comment.UserID, comment.ID, "second draft")
+			assert.NoError(t, err)
+			assert.Equal(t, "second draft", edited.Content)
+			assert.Equal(t, tc.expected, edited.Status)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+// FeedService assembles feeds with fan-out-on-write, posts of authors with more
+// followers than the fan-out limit are queried when the feed is read instead
+type FeedService struct {
+	// fanOutLimit is the most followers an author's posts are fanned out to
+	fanOutLimit int64
+	// maxLength is the most entries kept in a stored feed
+	maxLength  int64
+	followRepo port.FollowRepository
+	postRepo   port.PostRepository
+	feedRepo   port.FeedRepository
+}
+
+func NewFeedService(fanOutLimit, maxLength int64, followRepo port.FollowRepository, postRepo port.PostRepository, feedRepo port.FeedRepository) *FeedService {
+	return &FeedService{
+		fanOutLimit,
+		maxLength,
+		followRepo,
+		postRepo,
+		feedRepo,
+	}
+}
+
+func (fs *FeedService) FanOutPost(ctx context.Context, post *domain.Post) error {
+	if !post.Published || post.PublishedAt == nil {
+		return nil
+	}
+
+	counts, err := fs.followRepo.CountFollows(ctx, post.UserID)
+	if err != nil {
+		return err
+	}
+
+	// followers of large authors read their posts from the db
+	if counts.Followers == 0 || counts.Followers > fs.fanOutLimit {
+		return nil
+	}
+
+	followerIDs, err := fs.followRepo.GetFollowerIDs(ctx, post.UserID)
+	if err != nil {
+		return err
+	}
+
+	return fs.feedRepo.AddToFeeds(ctx, followerIDs, domain.FeedEntry{
+		PostID:      post.ID,
+		PublishedAt: *post.PublishedAt,
+	}, fs.maxLength)
+}
+
+// GetFeed returns the newest published posts of the followed users
+func (fs *FeedService) GetFeed(ctx context.Context, userID uint, start, end uint64) ([]domain.Post, error) {
+	// rebuild missing or unavailable feed from the db
+	entries, ok, err := fs.feedRepo.GetFeed(ctx, userID, 0, end)
+	if err != nil {
+		slog.Warn("unable to read feed, rebuilding it", "user_id", userID, "error", err)
+	}
+
+	if err != nil || !ok {
+		entries, err = fs.rebuildFeed(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if uint64(len(entries)) > end+1 {
+			entries = entries[:end+1]
+		}
+	}
+
+	// merge the posts of large authors, which aren't fanned out
+	largeIDs, err := fs.followRepo.GetLargeFollowees(ctx, userID, fs.fanOutLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(largeIDs) > 0 {
+		largeEntries, err := fs.postRepo.GetFeedEntries(ctx, largeIDs, int(end+1))
+		if err != nil {
+			return nil, err
+		}
+
+		entries = mergeFeedEntries(entries, largeEntries)
+	}
+
+	if start >= uint64(len(entries)) {
+		return []domain.Post{}, nil
+	}
+	entries = entries[start:min(end+1, uint64(len(entries)))]
+
+	ids := make([]uint, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.PostID
+	}
+
+	return fs.postRepo.GetPublishedPostsByIDs(ctx, ids)
+}
+
+func (fs *FeedService) rebuildFeed(ctx context.Context, userID uint) ([]domain.FeedEntry, error) {
+	followeeIDs, err := fs.followRepo.GetFolloweeIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := fs.postRepo.GetFeedEntries(ctx, followeeIDs, int(fs.maxLength))
+	if err != nil {
+		return nil, err
+	}
+
+	// the rebuilt feed is served even if it can't be stored
+	if err := fs.feedRepo.ReplaceFeed(ctx, userID, entries); err != nil {
+		slog.Warn("unable to store rebuilt feed", "user_id", userID, "error", err)
+	}
+
+	return entries, nil
+}
+
+// merges entries sorted newest first, dropping duplicate posts
+func mergeFeedEntries(a, b []domain.FeedEntry) []domain.FeedEntry {
+	merged := make([]domain.FeedEntry, 0, len(a)+len(b))
+	seen := map[uint]struct{}{}
+
+	add := func(entry domain.FeedEntry) {
+		if _, ok := seen[entry.PostID]; ok {
+			return
+		}
+		seen[entry.PostID] = struct{}{}
+		merged = append(merged, entry)
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i].PublishedAt.After(b[j].PublishedAt) {
+			add(a[i])
+			i++
+		} else {
+			add(b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		add(a[i])
+	}
+	for ; j < len(b); j++ {
+		add(b[j])
+	}
+
+	return merged
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/mocks"
+)
+
+func feedEntry(postID uint, minutesAgo int) domain.FeedEntry {
+	return domain.FeedEntry{
+		PostID:      postID,
+		PublishedAt: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC).Add(-time.Duration(minutesAgo) * time.Minute),
+	}
+}
+
+func feedPostIDs(entries []domain.FeedEntry) []uint {
+	ids := make([]uint, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.PostID
+	}
+
+	return ids
+}
+
+func TestMergeFeedEntries(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		a        []domain.FeedEntry
+		b        []domain.FeedEntry
+		expected []uint
+	}{
+		{
+			desc:     "Empty",
+			expected: []uint{},
+		},
+		{
+			desc:     "Interleaved",
+			a:        []domain.FeedEntry{feedEntry(1, 1), feedEntry(3, 3), feedEntry(5, 5)},
+			b:        []domain.FeedEntry{feedEntry(2, 2), feedEntry(4, 4)},
+			expected: []uint{1, 2, 3, 4, 5},
+		},
+		{
+			desc:     "OneSideExhausted",
+			a:        []domain.FeedEntry{feedEntry(1, 1)},
+			b:        []domain.FeedEntry{feedEntry(2, 2), feedEntry(3, 3)},
+			expected: []uint{1, 2, 3},
+		},
+		{
+			desc:     "Duplicates",
+			a:        []domain.FeedEntry{feedEntry(1, 1), feedEntry(2, 2)},
+			b:        []domain.FeedEntry{feedEntry(2, 2), feedEntry(3, 3)},
+			expected: []uint{1, 2, 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, feedPostIDs(mergeFeedEntries(tc.a, tc.b)))
+		})
+	}
+}
+
+func TestFeedService_GetFeed(t *testing.T) {
+	ctx := context.Background()
+	userID := uint(1)
+	posts := []domain.Post{{Title: "a"}, {Title: "b"}}
+
+	testCases := []struct {
+		desc     string
+		mocks    func(*mocks.FollowRepository, *mocks.PostRepository, *mocks.FeedRepository)
+		start    uint64
+		end      uint64
+		expected []uint
+	}{
+		{
+			desc: "StoredFeed",
+			mocks: func(fr *mocks.FollowRepository, pr *mocks.PostRepository, feed *mocks.FeedRepository) {
+				feed.On("GetFeed", ctx, userID, uint64(0), uint64(1)).Return([]domain.FeedEntry{feedEntry(10, 1), feedEntry(11, 2)}, true, nil).Once()
+				fr.On("GetLargeFollowees", ctx, userID, int64(100)).Return([]uint{}, nil).Once()
+			},
+			end:      1,
+			expected: []uint{10, 11},
+		},
+		{
+			desc: "LargeAuthorsMerged",
+			mocks: func(fr *mocks.FollowRepository, pr *mocks.PostRepository, feed *mocks.FeedRepository) {
+				feed.On("GetFeed", ctx, userID, uint64(0), uint64(2)).Return([]domain.FeedEntry{feedEntry(10, 1), feedEntry(11, 5)}, true, nil).Once()
+				fr.On("GetLargeFollowees", ctx, userID, int64(100)).Return([]uint{7}, nil).Once()
+				pr.On("GetFeedEntries", ctx, []uint{7}, 3).Return([]domain.FeedEntry{feedEntry(20, 3), feedEntry(21, 9)}, nil).Once()
+			},
+			start:    1,
+			end:      2,
+			expected: []uint{20, 11},
+		},
+		{
+			desc: "MissingFeedRebuilt",
+			mocks: func(fr *mocks.FollowRepository, pr *mocks.PostRepository, feed *mocks.FeedRepository) {
+				rebuilt := []domain.FeedEntry{feedEntry(10, 1), feedEntry(11, 2), feedEntry(12, 3)}
+
+				feed.On("GetFeed", ctx, userID, uint64(0), uint64(1)).Return(nil, false, nil).Once()
+				fr.On("GetFolloweeIDs", ctx, userID).Return([]uint{2, 3}, nil).Once()
+				pr.On("GetFeedEntries", ctx, []uint{2, 3}, 50).Return(rebuilt, nil).Once()
+				feed.On("ReplaceFeed", ctx, userID, rebuilt).Return(nil).Once()
+				fr.On("GetLargeFollowees", ctx, userID, int64(100)).Return([]uint{}, nil).Once()
+			},
+			end:      1,
+			expected: []uint{10, 11},
+		},
+		{
+			desc: "UnavailableFeedRebuilt",
+			mocks: func(fr *mocks.FollowRepository, pr *mocks.PostRepository, feed *mocks.FeedRepository) {
+				rebuilt := []domain.FeedEntry{feedEntry(10, 1)}
+
+				feed.On("GetFeed", ctx, userID, uint64(0), uint64(1)).Return(nil, false, errors.New("redis unavailable")).Once()
+				fr.On("GetFolloweeIDs", ctx, userID).Return([]uint{2}, nil).Once()
+				pr.On("GetFeedEntries", ctx, []uint{2}, 50).Return(rebuilt, nil).Once()
+				feed.On("ReplaceFeed", ctx, userID, rebuilt).Return(errors.New("redis unavailable")).Once()
+				fr.On("GetLargeFollowees", ctx, userID, int64(100)).Return([]uint{}, nil).Once()
+			},
+			end:      1,
+			expected: []uint{10},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fr := mocks.NewFollowRepository(t)
+			pr := mocks.NewPostRepository(t)
+			feed := mocks.NewFeedRepository(t)
+			tc.mocks(fr, pr, feed)
+
+			pr.On("GetPublishedPostsByIDs", ctx, tc.expected).Return(posts, nil).Once()
+
+			s := NewFeedService(100, 50, fr, pr, feed)
+			res, err := s.GetFeed(ctx, userID, tc.start, tc.end)
+
+			assert.NoError(t, err)
+			assert.Equal(t, posts, res)
+		})
+	}
+}
+
+func TestFeedService_FanOutPost(t *testing.T) {
+	ctx := context.Background()
+	publishedAt := time.Now()
+	post := &domain.Post{UserID: 1, Published: true, PublishedAt: &publishedAt}
+
+	testCases := []struct {
+		desc  string
+		mocks func(*mocks.FollowRepository, *mocks.FeedRepository)
+	}{
+		{
+			desc: "FannedOut",
+			mocks: func(fr *mocks.FollowRepository, feed *mocks.FeedRepository) {
+				fr.On("CountFollows", ctx, post.UserID).Return(&domain.FollowCounts{Followers: 2}, nil).Once()
+				fr.On("GetFollowerIDs", ctx, post.UserID).Return([]uint{2, 3}, nil).Once()
+				feed.On("AddToFeeds", ctx, []uint{2, 3}, mock.Anything, int64(50)).Return(nil).Once()
+			},
+		},
+		{
+			desc: "LargeAuthorSkipped",
+			mocks: func(fr *mocks.FollowRepository, feed *mocks.FeedRepository) {
+				fr.On("CountFollows", ctx, post.UserID).Return(&domain.FollowCounts{Followers: 101}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fr := mocks.NewFollowRepository(t)
+			feed := mocks.NewFeedRepository(t)
+			tc.mocks(fr, feed)
+
+			s := NewFeedService(100, 50, fr, mocks.NewPostRepository(t), feed)
+			assert.NoError(t, s.FanOutPost(ctx, post))
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/util"
+)
+
+type FollowService struct {
+	repo     port.FollowRepository
+	userRepo port.UserRepository
+	feedRepo port.FeedRepository
+	tx       port.Transactor
+	events   port.EventBus
+	cache    port.CacheRepository
+}
+
+func NewFollowService(repo port.FollowRepository, userRepo port.UserRepository, feedRepo port.FeedRepository, tx port.Transactor, events port.EventBus, cache port.CacheRepository) *FollowService {
+	return &FollowService{
+		repo,
+		userRepo,
+		feedRepo,
+		tx,
+		events,
+		cache,
+	}
+}
+
+func (fs *FollowService) Follow(ctx context.Context, followerID, followeeID uint) error {
+	if followerID == followeeID {
+		return domain.ErrBadRequest
+	}
+
+	// check followee exists
+	if _, err := fs.userRepo.GetUserByID(ctx, followeeID); err != nil {
+		return domain.ErrNotFound
+	}
+
+	// create follow, its event is stored in the same transaction
+	err := fs.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := fs.repo.CreateFollow(ctx, &domain.Follow{
+			FollowerID: followerID,
+			FolloweeID: followeeID,
+		}); err != nil {
+			return err
+		}
+
+		return fs.events.Publish(ctx, domain.NewEvent(domain.EventUserFollowed, followerID, followeeID))
+	})
+	if err != nil {
+		return err
+	}
+
+	// the follow is stored, the caches are refreshed on a best effort basis
+	fs.clearCaches(ctx, followerID, followeeID)
+
+	return nil
+}
+
+func (fs *FollowService) Unfollow(ctx context.Context, followerID, followeeID uint) error {
+	if err := fs.repo.DeleteFollow(ctx, followerID, followeeID); err != nil {
+		return err
+	}
+
+	// the unfollow is stored, the caches are refreshed on a best effort basis
+	fs.clearCaches(ctx, followerID, followeeID)
+
+	return nil
+}
+
+func (fs *FollowService) GetFollowCounts(ctx context.Context, userID uint) (*domain.FollowCounts, error) {
+	return fs.repo.CountFollows(ctx, userID)
+}
+
+// clearCaches invalidates the cached counts of both users, they're tagged with
+// the user, and has the follower's feed rebuilt with the new followees on the
+// next read
+func (fs *FollowService) clearCaches(ctx context.Context, followerID, followeeID uint) {
+	if err := fs.cache.InvalidateTags(ctx, util.CacheTag("user", followerID), util.CacheTag("user", followeeID)); err != nil {
+		slog.Warn("unable to invalidate follow counts", "follower_id", followerID, "followee_id", followeeID, "error", err)
+	}
+
+	if err := fs.feedRepo.DeleteFeed(ctx, followerID); err != nil {
+		slog.Warn("unable to delete feed", "user_id", followerID, "error", err)
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/util"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/mocks"
+)
+
+func TestFollowService_Follow(t *testing.T) {
+	ctx := context.Background()
+	followerID, followeeID := uint(1), uint(2)
+
+	testCases := []struct {
+		desc       string
+		followeeID uint
+		mocks      func(*mocks.FollowRepository, *mocks.UserRepository, *mocks.FeedRepository, *mocks.CacheRepository)
+		err        error
+	}{
+		{
+			desc:       "Success",
+			followeeID: followeeID,
+			mocks: func(fr *mocks.FollowRepository, ur *mocks.UserRepository, feed *mocks.FeedRepository, cr *mocks.CacheRepository) {
+				ur.On("GetUserByID", ctx, followeeID).Return(&domain.User{ID: followeeID}, nil).Once()
+				fr.On("CreateFollow", ctx, mock.Anything).Return(&domain.Follow{FollowerID: followerID, FolloweeID: followeeID}, nil).Once()
+				cr.On("InvalidateTags", ctx, util.CacheTag("user", followerID), util.CacheTag("user", followeeID)).Return(nil).Once()
+				feed.On("DeleteFeed", ctx, followerID).Return(nil).Once()
+			},
+		},
+		{
+			desc:       "Success_FeedUnavailable",
+			followeeID: followeeID,
+			mocks: func(fr *mocks.FollowRepository, ur *mocks.UserRepository, feed *mocks.FeedRepository, cr *mocks.CacheRepository) {
+				ur.On("GetUserByID", ctx, followeeID).Return(&domain.User{ID: followeeID}, nil).Once()
+				fr.On("CreateFollow", ctx, mock.Anything).Return(&domain.Follow{FollowerID: followerID, FolloweeID: followeeID}, nil).Once()
+				cr.On("InvalidateTags", ctx, util.CacheTag("user", followerID), util.CacheTag("user", followeeID)).Return(nil).Once()
+				feed.On("DeleteFeed", ctx, followerID).Return(errors.New("redis unavailable")).Once()
+			},
+		},
+		{
+			desc:       "Fail_Self",
+			followeeID: followerID,
+			mocks: func(fr *mocks.FollowRepository, ur *mocks.UserRepository, feed *mocks.FeedRepository, cr *mocks.CacheRepository) {
+			},
+			err: domain.ErrBadRequest,
+		},
+		{
+			desc:       "Fail_FolloweeNotFound",
+			followeeID: followeeID,
+			mocks: func(fr *mocks.FollowRepository, ur *mocks.UserRepository, feed *mocks.FeedRepository, cr *mocks.CacheRepository) {
+				ur.On("GetUserByID", ctx, followeeID).Return(nil, domain.ErrNotFound).Once()
+			},
+			err: domain.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fr := mocks.NewFollowRepository(t)
+			ur := mocks.NewUserRepository(t)
+			feed := mocks.NewFeedRepository(t)
+			cr := mocks.NewCacheRepository(t)
+			tc.mocks(fr, ur, feed, cr)
+
+			s := NewFollowService(fr, ur, feed, fakeTransactor{}, newEventBusMock(), cr)
+			err := s.Follow(ctx, followerID, tc.followeeID)
+
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestFollowService_Unfollow(t *testing.T) {
+	ctx := context.Background()
+	followerID, followeeID := uint(1), uint(2)
+
+	fr := mocks.NewFollowRepository(t)
+	fr.On("DeleteFollow", ctx, followerID, followeeID).Return(nil).Once()
+
+	// the counts of both users change and the feed is rebuilt on the next read
+	cr := mocks.NewCacheRepository(t)
+	cr.On("InvalidateTags", ctx, util.CacheTag("user", followerID), util.CacheTag("user", followeeID)).Return(nil).Once()
+
+	feed := mocks.NewFeedRepository(t)
+	feed.On("DeleteFeed", ctx, followerID).Return(nil).Once()
+
+	s := NewFollowService(fr, mocks.NewUserRepository(t), feed, fakeTransactor{}, newEventBusMock(), cr)
+	assert.NoError(t, s.Unfollow(ctx, followerID, followeeID))
+}
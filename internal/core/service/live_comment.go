@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/util"
+)
+
+type LiveCommentService struct {
+	repo   port.CommentRepository
+	pubsub port.PubSub
+}
+
+func NewLiveCommentService(repo port.CommentRepository, pubsub port.PubSub) *LiveCommentService {
+	return &LiveCommentService{
+		repo,
+		pubsub,
+	}
+}
+
+func (ls *LiveCommentService) HandleEvent(ctx context.Context, event domain.Event) error {
+	switch event.Type {
+	case domain.EventCommentCreated, domain.EventCommentEdited, domain.EventCommentDeleted:
+	default:
+		return nil
+	}
+
+	comment, err := ls.repo.GetCommentByID(ctx, event.CommentID)
+	if err != nil {
+		return err
+	}
+
+	// comments awaiting moderation aren't public, an edit sending one back to
+	// moderation removes it from the live readers
+	if comment.Status != domain.CommentApproved {
+		if event.Type != domain.EventCommentEdited {
+			return nil
+		}
+
+		return ls.publish(ctx, &domain.CommentLiveMessage{
+			Type:    string(domain.EventCommentDeleted),
+			PostID:  comment.PostID,
+			UserID:  event.ActorID,
+			Comment: &domain.Comment{ID: comment.ID, PostID: comment.PostID},
+		})
+	}
+
+	return ls.publish(ctx, &domain.CommentLiveMessage{
+		Type:    string(event.Type),
+		PostID:  comment.PostID,
+		UserID:  event.ActorID,
+		Comment: comment,
+	})
+}
+
+func (ls *LiveCommentService) Typing(ctx context.Context, postID, userID uint) error {
+	return ls.publish(ctx, &domain.CommentLiveMessage{
+		Type:   domain.CommentTyping,
+		PostID: postID,
+		UserID: userID,
+	})
+}
+
+func (ls *LiveCommentService) Subscribe(ctx context.Context, postID uint) (<-chan domain.CommentLiveMessage, error) {
+	payloads, err := ls.pubsub.Subscribe(ctx, liveCommentsTopic(postID))
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make(chan domain.CommentLiveMessage)
+	go func() {
+		defer close(msgs)
+
+		for payload := range payloads {
+			var msg domain.CommentLiveMessage
+			if err := json.Unmarshal(payload, &msg); err != nil {
+				slog.Error("invalid live comment message", "error", err)
+				continue
+			}
+
+			select {
+			case msgs <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return msgs, nil
+}
+
+func (ls *LiveCommentService) publish(ctx context.Context, msg *domain.CommentLiveMessage) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return ls.pubsub.Publish(ctx, liveCommentsTopic(msg.PostID), payload)
+}
+
+func liveCommentsTopic(postID uint) string {
+	return util.GenerateCacheKey("live:comments", postID)
+}
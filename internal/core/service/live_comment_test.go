@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/storage/memory"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/mocks"
+)
+
+func TestLiveCommentService_HandleEvent(t *testing.T) {
+	postID := uint(gofakeit.Number(1, 100))
+	userID := uint(gofakeit.Number(1, 100))
+
+	comment := &domain.Comment{
+		ID:      uint(gofakeit.Number(1, 100)),
+		PostID:  postID,
+		UserID:  userID,
+		Content: gofakeit.Sentence(5),
+		Status:  domain.CommentApproved,
+	}
+
+	pending := *comment
+	pending.Status = domain.CommentPending
+
+	testCases := []struct {
+		desc      string
+		eventType domain.EventType
+		comment   *domain.Comment
+		expected  *domain.CommentLiveMessage
+	}{
+		{
+			desc:      "Success_Created",
+			eventType: domain.EventCommentCreated,
+			comment:   comment,
+			expected: &domain.CommentLiveMessage{
+				Type:    string(domain.EventCommentCreated),
+				PostID:  postID,
+				UserID:  userID,
+				Comment: comment,
+			},
+		},
+		{
+			desc:      "Success_Edited",
+			eventType: domain.EventCommentEdited,
+			comment:   comment,
+			expected: &domain.CommentLiveMessage{
+				Type:    string(domain.EventCommentEdited),
+				PostID:  postID,
+				UserID:  userID,
+				Comment: comment,
+			},
+		},
+		{
+			desc:      "Skip_Pending",
+			eventType: domain.EventCommentCreated,
+			comment:   &pending,
+			expected:  nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			cr := new(mocks.CommentRepository)
+			cr.On("GetCommentByID", ctx, tc.comment.ID).Return(tc.comment, nil).Once()
+
+			s := NewLiveCommentService(cr, memory.NewPubSub())
+			msgs, err := s.Subscribe(ctx, postID)
+			assert.NoError(t, err)
+
+			event := domain.NewEvent(tc.eventType, userID, userID)
+			event.PostID = postID
+			event.CommentID = tc.comment.ID
+			assert.NoError(t, s.HandleEvent(ctx, event))
+
+			select {
+			case msg := <-msgs:
+				assert.NotNil(t, tc.expected)
+				assert.Equal(t, tc.expected.Type, msg.Type)
+				assert.Equal(t, tc.expected.PostID, msg.PostID)
+				assert.Equal(t, tc.expected.UserID, msg.UserID)
+				assert.Equal(t, tc.expected.Comment.Content, msg.Comment.Content)
+			case <-time.After(100 * time.Millisecond):
+				assert.Nil(t, tc.expected)
+			}
+
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLiveCommentService_Typing(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	postID := uint(gofakeit.Number(1, 100))
+	userID := uint(gofakeit.Number(1, 100))
+
+	s := NewLiveCommentService(new(mocks.CommentRepository), memory.NewPubSub())
+
+	msgs, err := s.Subscribe(ctx, postID)
+	assert.NoError(t, err)
+
+	// other posts' readers don't receive it
+	other, err := s.Subscribe(ctx, postID+1)
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.Typing(ctx, postID, userID))
+
+	select {
+	case msg := <-msgs:
+		assert.Equal(t, domain.CommentTyping, msg.Type)
+		assert.Equal(t, userID, msg.UserID)
+	case <-time.After(time.Second):
+		t.Fatal("typing not delivered")
+	}
+
+	select {
+	case <-other:
+		t.Fatal("typing delivered to another post")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/util"
+)
+
+// notification messages by event type, %s is the actor's name
+var notificationMessages = map[domain.EventType]string{
+	domain.EventCommentCreated:    "%s commented on your post",
+	domain.EventCommentReplied:    "%s replied to your comment",
+	domain.EventCommentDeleted:    "Your comment was removed by %s",
+	domain.EventPostDeleted:       "Your post was removed by %s",
+	domain.EventUserFollowed:      "%s started following you",
+	domain.EventTrustLevelChanged: "Your trust level was changed by %s",
+}
+
+type NotificationService struct {
+	repo        port.NotificationRepository
+	userRepo    port.UserRepository
+	stream      port.StreamService
+	unreadCache *util.CacheAside[int64]
+}
+
+func NewNotificationService(repo port.NotificationRepository, userRepo port.UserRepository, stream port.StreamService, cache port.CacheRepository, policy *util.CachePolicy) *NotificationService {
+	return &NotificationService{
+		repo,
+		userRepo,
+		stream,
+		util.NewCacheAside[int64](cache, "unread", policy, nil),
+	}
+}
+
+func (ns *NotificationService) HandleEvent(ctx context.Context, event domain.Event) error {
+	message, ok := notificationMessages[event.Type]
+	if !ok {
+		return nil
+	}
+
+	// users aren't notified of their own actions
+	if event.UserID == 0 || event.UserID == event.ActorID {
+		return nil
+	}
+
+	actorName := "the system"
+	if event.ActorID != 0 {
+		actor, err := ns.userRepo.GetUserByID(ctx, event.ActorID)
+		if err != nil {
+			return err
+		}
+		actorName = actor.Name
+	}
+
+	notification, err := ns.repo.CreateNotification(ctx, &domain.Notification{
+		UserID:    event.UserID,
+		Type:      event.Type,
+		ActorID:   event.ActorID,
+		Message:   fmt.Sprintf(message, actorName),
+		PostID:    event.PostID,
+		CommentID: event.CommentID,
+	})
+	if err != nil {
+		return err
+	}
+
+	// the notification is stored, retrying the event would store it again, so
+	// the unread count and the push are best effort
+	if err := ns.clearUnreadCount(ctx, event.UserID); err != nil {
+		slog.Warn("unable to clear unread count", "user_id", event.UserID, "error", err)
+	}
+
+	// push to the recipient's open streams
+	if err := ns.stream.Send(ctx, notification.UserID, "notification.created", notification); err != nil {
+		slog.Warn("unable to push notification", "id", notification.ID, "user_id", notification.UserID, "error", err)
+	}
+
+	return nil
+}
+
+func (ns *NotificationService) GetNotifications(ctx context.Context, userID uint, unreadOnly bool, start, end uint64) ([]domain.Notification, error) {
+	return ns.repo.GetNotifications(ctx, userID, unreadOnly, start, end)
+}
+
+func (ns *NotificationService) MarkRead(ctx context.Context, userID uint, ids []uint) error {
+	if len(ids) == 0 {
+		return domain.ErrBadRequest
+	}
+
+	updated, err := ns.repo.MarkRead(ctx, userID, ids)
+	if err != nil {
+		return err
+	}
+
+	if updated == 0 {
+		return nil
+	}
+
+	return ns.clearUnreadCount(ctx, userID)
+}
+
+func (ns *NotificationService) MarkAllRead(ctx context.Context, userID uint) error {
+	updated, err := ns.repo.MarkAllRead(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if updated == 0 {
+		return nil
+	}
+
+	return ns.clearUnreadCount(ctx, userID)
+}
+
+func (ns *NotificationService) GetUnreadCount(ctx context.Context, userID uint) (int64, error) {
+	return ns.unreadCache.Get(ctx, userID, func(ctx context.Context) (int64, error) {
+		return ns.repo.CountUnread(ctx, userID)
+	})
+}
+
+func (ns *NotificationService) clearUnreadCount(ctx context.Context, userID uint) error {
+	return ns.unreadCache.Delete(ctx, userID)
+}
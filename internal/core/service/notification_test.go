@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/mocks"
+)
+
+// failingStreamService fails every push
+type failingStreamService struct {
+	port.StreamService
+}
+
+func (failingStreamService) Send(ctx context.Context, userID uint, msgType string, payload any) error {
+	return errors.New("broker unavailable")
+}
+
+func TestNotificationService_HandleEvent(t *testing.T) {
+	ctx := context.Background()
+	event := domain.NewEvent(domain.EventUserFollowed, 1, 2)
+
+	testCases := []struct {
+		desc  string
+		mocks func(nr *mocks.NotificationRepository, cr *mocks.CacheRepository)
+		err   error
+	}{
+		{
+			desc: "Success_RedisUnavailable",
+			mocks: func(nr *mocks.NotificationRepository, cr *mocks.CacheRepository) {
+				nr.On("CreateNotification", ctx, mock.Anything).Return(&domain.Notification{ID: 1, UserID: 2}, nil).Once()
+				cr.On("Delete", ctx, "unread:2").Return(errors.New("connection refused"))
+			},
+		},
+		{
+			desc: "Fail_CreateNotification",
+			mocks: func(nr *mocks.NotificationRepository, cr *mocks.CacheRepository) {
+				nr.On("CreateNotification", ctx, mock.Anything).Return(nil, errors.New("connection reset")).Once()
+			},
+			err: errors.New("connection reset"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			nr := mocks.NewNotificationRepository(t)
+			cr := mocks.NewCacheRepository(t)
+			tc.mocks(nr, cr)
+
+			ur := mocks.NewUserRepository(t)
+			ur.On("GetUserByID", ctx, uint(1)).Return(&domain.User{ID: 1, Name: "John"}, nil)
+
+			// a failed handler is retried, so it only fails before the notification is stored
+			s := NewNotificationService(nr, ur, failingStreamService{}, cr, nil)
+			err := s.HandleEvent(ctx, event)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+// messages relayed per batch
+const outboxBatchSize = 100
+
+// OutboxService relays the outbox to the publisher, messages are only deleted
+// once published so every event is delivered at least once
+type OutboxService struct {
+	repo      port.OutboxRepository
+	publisher port.EventPublisher
+}
+
+func NewOutboxService(repo port.OutboxRepository, publisher port.EventPublisher) *OutboxService {
+	return &OutboxService{
+		repo,
+		publisher,
+	}
+}
+
+func (obs *OutboxService) Relay(ctx context.Context) (int, error) {
+	relayed := 0
+
+	for {
+		msgs, err := obs.repo.GetOutboxMessages(ctx, outboxBatchSize)
+		if err != nil {
+			return relayed, err
+		}
+
+		if len(msgs) == 0 {
+			return relayed, nil
+		}
+
+		ids := make([]uint, len(msgs))
+		events := make([]domain.Event, 0, len(msgs))
+		for i, msg := range msgs {
+			ids[i] = msg.ID
+
+			// undecodable messages would block the outbox, drop them
+			var event domain.Event
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				slog.Error("unable to decode outbox message", "id", msg.ID, "event_id", msg.EventID, "error", err)
+				continue
+			}
+			events = append(events, event)
+		}
+
+		if err := obs.publisher.Publish(ctx, events...); err != nil {
+			return relayed, err
+		}
+
+		if err := obs.repo.DeleteOutboxMessages(ctx, ids); err != nil {
+			return relayed, err
+		}
+		relayed += len(events)
+
+		if len(msgs) < outboxBatchSize {
+			return relayed, nil
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/mocks"
+)
+
+func TestOutboxService_Relay(t *testing.T) {
+	ctx := context.Background()
+
+	event := domain.NewEvent(domain.EventPostCreated, 1, 1)
+	payload, err := json.Marshal(event)
+	assert.NoError(t, err)
+
+	msgs := []domain.OutboxMessage{
+		{ID: 1, EventID: event.ID, EventType: event.Type, Payload: string(payload)},
+		{ID: 2, EventID: "broken", EventType: event.Type, Payload: "{"},
+	}
+
+	testCases := []struct {
+		desc       string
+		publishErr error
+		expected   int
+		expectErr  bool
+	}{
+		{desc: "Success", publishErr: nil, expected: 1, expectErr: false},
+		{desc: "PublishFailed_KeepsMessages", publishErr: errors.New("unavailable"), expected: 0, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			or := new(mocks.OutboxRepository)
+			ep := new(mocks.EventPublisher)
+
+			or.On("GetOutboxMessages", ctx, outboxBatchSize).Return(msgs, nil).Once()
+			ep.On("Publish", ctx, mock.MatchedBy(func(e domain.Event) bool {
+				return e.ID == event.ID
+			})).Return(tc.publishErr).Once()
+			if tc.publishErr == nil {
+				// undecodable messages are dropped with the published ones
+				or.On("DeleteOutboxMessages", ctx, []uint{1, 2}).Return(nil).Once()
+			}
+
+			s := NewOutboxService(or, ep)
+			relayed, err := s.Relay(ctx)
+
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, relayed)
+
+			or.AssertExpectations(t)
+			ep.AssertExpectations(t)
+		})
+	}
+}
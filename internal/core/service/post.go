@@ -2,201 +2,537 @@ package service
 
 import (
 	"context"
-	"strings"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
 
-	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/storage/redis"
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/util"
 )
 
+// number of attempts when a concurrent insert takes the generated slug
+const slugAttempts = 3
+
 type PostService struct {
-	repo  port.PostRepository
-	cache port.CacheRepository
+	repo         port.PostRepository
+	revisionRepo port.PostRevisionRepository
+	bookmarkRepo port.BookmarkRepository
+	slugSvc      port.SlugService
+	tagSvc       port.TagService
+	reactionSvc  port.ReactionService
+	feedSvc      port.FeedService
+	tx           port.Transactor
+	events       port.EventBus
+	cache        port.CacheRepository
+	postCache    *util.CacheAside[domain.Post]
+	postsCache   *util.CacheAside[[]domain.Post]
 }
 
-func NewPostService(repo port.PostRepository, cache *redis.Redis) *PostService {
+func NewPostService(repo port.PostRepository, revisionRepo port.PostRevisionRepository, bookmarkRepo port.BookmarkRepository, slugSvc port.SlugService, tagSvc port.TagService, reactionSvc port.ReactionService, feedSvc port.FeedService, tx port.Transactor, events port.EventBus, cache port.CacheRepository, policy *util.CachePolicy) *PostService {
 	return &PostService{
 		repo,
+		revisionRepo,
+		bookmarkRepo,
+		slugSvc,
+		tagSvc,
+		reactionSvc,
+		feedSvc,
+		tx,
+		events,
 		cache,
+		util.NewCacheAside(cache, "post", policy, util.EntityCacheTags[domain.Post]("post")),
+		util.NewCacheAside(cache, "posts", policy, util.ListCacheTags("posts", "post", postCacheID)),
 	}
 }
 
 func (ps *PostService) CreatePost(ctx context.Context, post *domain.Post) (*domain.Post, error) {
-	// create post
-	post, err := ps.repo.CreatePost(ctx, post)
-	if err != nil {
-		return nil, err
+	// scheduled posts stay unpublished until their publish time
+	if post.PublishAt != nil {
+		post.Published = !post.PublishAt.After(time.Now())
 	}
 
-	// set cache
-	cacheKey := util.GenerateCacheKey("post", post.ID)
-	postSerialized, err := util.Serialize(post)
+	setPublishedAt(post)
+
+	// tags are assigned once the post exists
+	tagNames := getTagNames(post.Tags)
+	post.Tags = nil
+
+	// create post with its tags, initial revision and event in one transaction
+	var changedTags []domain.Tag
+	err := ps.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := ps.saveWithSlug(ctx, post, "", func(post *domain.Post) error {
+			_, err := ps.repo.CreatePost(ctx, post)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		if changedTags, err = ps.tagSvc.SetPostTags(ctx, post, tagNames); err != nil {
+			return err
+		}
+
+		if err := ps.createRevision(ctx, post.UserID, post); err != nil {
+			return err
+		}
+
+		return ps.publishPostEvent(ctx, domain.EventPostCreated, post.UserID, post)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err := ps.cache.Set(ctx, cacheKey, postSerialized, 0); err != nil {
+	// clear tags cache
+	if err := ps.tagSvc.InvalidateTags(ctx, changedTags...); err != nil {
 		return nil, err
 	}
 
 	// clear posts cache (since new post created)
-	if err := ps.cache.DeleteByPrefix(ctx, "posts"); err != nil {
+	if err := ps.invalidatePosts(ctx); err != nil {
+		return nil, err
+	}
+
+	// set cache
+	if err := ps.postCache.Set(ctx, post.ID, *post); err != nil {
 		return nil, err
 	}
 
+	// add to followers' feeds
+	if err := ps.feedSvc.FanOutPost(ctx, post); err != nil {
+		slog.Warn("unable to fan out post", "id", post.ID, "error", err)
+	}
+
 	return post, nil
 }
 
-func (ps *PostService) GetPosts(ctx context.Context, start, end uint64) ([]domain.Post, error) {
-	var posts []domain.Post
+// saveWithSlug generates the post's slug and saves the post, regenerating the
+// slug if a concurrent write took it
+func (ps *PostService) saveWithSlug(ctx context.Context, post *domain.Post, currentSlug string, save func(post *domain.Post) error) error {
+	for attempt := 1; ; attempt++ {
+		slug, err := ps.slugSvc.GenerateSlug(ctx, post.ID, currentSlug, post.Title)
+		if err != nil {
+			return err
+		}
+		post.Slug = slug
+
+		err = save(post)
+		if !errors.Is(err, domain.ErrConflictingData) || attempt == slugAttempts {
+			return err
+		}
+	}
+}
 
-	// generate cache key
+func (ps *PostService) GetPosts(ctx context.Context, start, end uint64) ([]domain.Post, error) {
 	param := util.GenerateCacheKeyParams(start, end)
-	cacheKey := util.GenerateCacheKey("posts", param)
+	posts, err := ps.postsCache.Get(ctx, param, func(ctx context.Context) ([]domain.Post, error) {
+		return ps.repo.GetPosts(ctx, start, end)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	// get from cache
-	postSerialized, err := ps.cache.Get(ctx, cacheKey)
-	if err == nil {
-		if err := util.Deserialize(postSerialized, &posts); err != nil {
-			return nil, err
+	// coalesced requests share the loaded posts, copy before embedding the counts
+	posts = slices.Clone(posts)
+	return posts, ps.setReactionCounts(ctx, posts)
+}
+
+func (ps *PostService) GetPostByID(ctx context.Context, id uint) (*domain.Post, error) {
+	post, err := ps.postCache.Get(ctx, id, func(ctx context.Context) (domain.Post, error) {
+		post, err := ps.repo.GetPostByID(ctx, id)
+		if err != nil {
+			return domain.Post{}, err
 		}
 
-		return posts, nil
+		return *post, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &post, ps.setPostReactionCounts(ctx, &post)
+}
+
+// setReactionCounts embeds the reaction counts, they change too often to be cached with the posts
+func (ps *PostService) setReactionCounts(ctx context.Context, posts []domain.Post) error {
+	ids := make([]uint, len(posts))
+	for i, post := range posts {
+		ids[i] = post.ID
 	}
 
-	// get from db if cache don't exist
-	posts, err = ps.repo.GetPosts(ctx, start, end)
+	counts, err := ps.reactionSvc.GetReactionCounts(ctx, ids...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// set cache
-	postsSerialized, err := util.Serialize(posts)
+	for i := range posts {
+		posts[i].Reactions = counts[posts[i].ID]
+	}
+
+	return nil
+}
+
+func (ps *PostService) setPostReactionCounts(ctx context.Context, post *domain.Post) error {
+	counts, err := ps.reactionSvc.GetReactionCounts(ctx, post.ID)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	if err := ps.cache.Set(ctx, cacheKey, postsSerialized, 0); err != nil {
+	post.Reactions = counts[post.ID]
+	return nil
+}
+
+// GetPostBySlug returns the post owning the current or a previous slug
+func (ps *PostService) GetPostBySlug(ctx context.Context, slug string) (*domain.Post, error) {
+	id, err := ps.slugSvc.ResolveSlug(ctx, slug)
+	if err != nil {
 		return nil, err
 	}
 
-	return posts, nil
+	return ps.GetPostByID(ctx, id)
 }
 
-func (ps *PostService) GetPostByID(ctx context.Context, id uint) (*domain.Post, error) {
-	post := &domain.Post{}
+// UpdatePost replaces all editable fields of a post
+func (ps *PostService) UpdatePost(ctx context.Context, userID uint, ifMatch domain.IfMatch, post *domain.Post) (*domain.Post, error) {
+	foundPost, err := ps.getPostForUpdate(ctx, post.ID, ifMatch)
+	if err != nil {
+		return nil, err
+	}
 
-	// generate cache key
-	cacheKey := util.GenerateCacheKey("post", id)
+	// replace post
+	foundPost.Title = post.Title
+	foundPost.Content = post.Content
+	foundPost.CategoryID = post.CategoryID
+	foundPost.Published = post.Published
+	foundPost.PublishAt = post.PublishAt
+	if post.PublishAt != nil {
+		foundPost.Published = !post.PublishAt.After(time.Now())
+	}
 
-	// get post from cache
-	postSerialized, err := ps.cache.Get(ctx, cacheKey)
-	if err == nil {
-		if err := util.Deserialize(postSerialized, post); err != nil {
-			return nil, err
-		}
+	tagNames := getTagNames(post.Tags)
 
-		return post, nil
-	}
+	return ps.savePost(ctx, userID, foundPost, &tagNames)
+}
 
-	// get post from db
-	post, err = ps.repo.GetPostByID(ctx, id)
+// PatchPost only updates the fields set in the patch
+func (ps *PostService) PatchPost(ctx context.Context, userID, id uint, ifMatch domain.IfMatch, patch *domain.PostPatch) (*domain.Post, error) {
+	foundPost, err := ps.getPostForUpdate(ctx, id, ifMatch)
 	if err != nil {
 		return nil, err
 	}
 
-	// cache post
-	postSerialized, err = util.Serialize(post)
+	// patch post
+	if patch.Title != nil {
+		foundPost.Title = *patch.Title
+	}
+	if patch.Content != nil {
+		foundPost.Content = *patch.Content
+	}
+	if patch.CategoryID != nil {
+		foundPost.CategoryID = *patch.CategoryID
+	}
+	if patch.Published != nil {
+		foundPost.Published = *patch.Published
+	}
+	if patch.ClearPublishAt {
+		foundPost.PublishAt = nil
+	}
+	if patch.PublishAt != nil {
+		foundPost.PublishAt = patch.PublishAt
+		foundPost.Published = !patch.PublishAt.After(time.Now())
+	}
+
+	// required fields can't be cleared
+	if foundPost.Title == "" || foundPost.Content == "" || foundPost.CategoryID == 0 {
+		return nil, domain.ErrBadRequest
+	}
+
+	return ps.savePost(ctx, userID, foundPost, patch.Tags)
+}
+
+func (ps *PostService) getPostForUpdate(ctx context.Context, id uint, ifMatch domain.IfMatch) (*domain.Post, error) {
+	// get post from db, the cache may hold a stale version
+	foundPost, err := ps.repo.GetPostByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := ps.cache.Set(ctx, cacheKey, postSerialized, 0); err != nil {
-		return nil, err
+	// reject updates based on an outdated version
+	if !ifMatch.Matches(foundPost.Version) {
+		return nil, domain.ErrPreconditionFailed
 	}
 
-	return post, nil
+	return foundPost, nil
 }
 
-func (ps *PostService) UpdatePost(ctx context.Context, post *domain.Post) (*domain.Post, error) {
-	foundPost := &domain.Post{}
+// savePost stores the updated post, tagNames replaces the tags unless nil
+func (ps *PostService) savePost(ctx context.Context, userID uint, post *domain.Post, tagNames *[]string) (*domain.Post, error) {
+	oldSlug := post.Slug
 
-	// generate cache key
-	cacheKey := util.GenerateCacheKey("post", post.ID)
+	// an unpublished post drops a passed schedule, the scheduler would publish it again
+	if !post.Published && post.PublishAt != nil && !post.PublishAt.After(time.Now()) {
+		post.PublishAt = nil
+	}
 
-	// get post from cache
-	postSerialized, err := ps.cache.Get(ctx, cacheKey)
-	if err == nil {
-		if err := util.Deserialize(postSerialized, foundPost); err != nil {
-			return nil, err
-		}
-	} else {
-		// get post from db (if doesn't exist in cache)
-		foundPost, err = ps.repo.GetPostByID(ctx, post.ID)
+	published := setPublishedAt(post)
+
+	// update post with the slug regenerated from the title, its slug redirect,
+	// tags, revision and event are stored in the same transaction
+	changedTags := post.Tags
+	err := ps.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := ps.saveWithSlug(ctx, post, oldSlug, func(post *domain.Post) error {
+			_, err := ps.repo.UpdatePost(ctx, post)
+			return err
+		})
 		if err != nil {
-			return nil, err
+			return err
+		}
+
+		// keep old slug as redirect
+		if err := ps.slugSvc.ChangeSlug(ctx, post.ID, oldSlug, post.Slug); err != nil {
+			return err
 		}
+
+		// replace tags, otherwise the post lists of the current tags changed
+		if tagNames != nil {
+			if changedTags, err = ps.tagSvc.SetPostTags(ctx, post, *tagNames); err != nil {
+				return err
+			}
+		}
+
+		// store revision of the updated post
+		if err := ps.createRevision(ctx, userID, post); err != nil {
+			return err
+		}
+
+		return ps.publishPostEvent(ctx, domain.EventPostUpdated, userID, post)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// update post
-	if post.Title != "" {
-		foundPost.Title = post.Title
-		slug := strings.Join(strings.Split(strings.ToLower(post.Title), " "), "-")
-		foundPost.Slug = slug
+	// clear tags cache
+	if err := ps.tagSvc.InvalidateTags(ctx, changedTags...); err != nil {
+		return nil, err
 	}
-	if post.Content != "" {
-		foundPost.Content = post.Content
+
+	// clear the post and posts cache
+	if err := ps.invalidatePosts(ctx, post.ID); err != nil {
+		return nil, err
 	}
-	if post.CategoryID != 0 {
-		foundPost.CategoryID = post.CategoryID
+
+	// set post cache
+	if err := ps.postCache.Set(ctx, post.ID, *post); err != nil {
+		return nil, err
 	}
-	if post.Published != false {
-		foundPost.Published = post.Published
+
+	// add newly published post to followers' feeds
+	if published {
+		if err := ps.feedSvc.FanOutPost(ctx, post); err != nil {
+			slog.Warn("unable to fan out post", "id", post.ID, "error", err)
+		}
 	}
 
-	post, err = ps.repo.UpdatePost(ctx, foundPost)
-	if err != nil {
+	return post, nil
+}
+
+// setPublishedAt keeps the publish time in sync with the published flag, returning
+// whether the post was just published
+func setPublishedAt(post *domain.Post) bool {
+	if !post.Published {
+		post.PublishedAt = nil
+		return false
+	}
+
+	if post.PublishedAt != nil {
+		return false
+	}
+
+	now := time.Now()
+	post.PublishedAt = &now
+	return true
+}
+
+// DeletePost deletes the post, actorID is the user deleting it
+func (ps *PostService) DeletePost(ctx context.Context, actorID, id uint) (*domain.Post, error) {
+	// clear the post and posts cache
+	if err := ps.invalidatePosts(ctx, id); err != nil {
 		return nil, err
 	}
 
-	// clear posts cache
-	if err := ps.cache.DeleteByPrefix(ctx, "posts:*"); err != nil {
+	// get post tags before deleting
+	foundPost, err := ps.repo.GetPostByID(ctx, id)
+	if err != nil {
 		return nil, err
 	}
 
-	// set post cache
-	postSerialized, err = util.Serialize(post)
+	// delete post from db and reading lists, its event is stored in the same
+	// transaction
+	var post *domain.Post
+	err = ps.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		deleted, err := ps.repo.DeletePost(ctx, id)
+		if err != nil {
+			return err
+		}
+		post = deleted
+
+		if err := ps.bookmarkRepo.DeletePostBookmarks(ctx, id); err != nil {
+			return err
+		}
+
+		return ps.publishPostEvent(ctx, domain.EventPostDeleted, actorID, foundPost)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err := ps.cache.Set(ctx, cacheKey, postSerialized, 0); err != nil {
+	// clear tags cache
+	if err := ps.tagSvc.InvalidateTags(ctx, foundPost.Tags...); err != nil {
 		return nil, err
 	}
 
 	return post, nil
 }
 
-func (ps *PostService) DeletePost(ctx context.Context, id uint) (*domain.Post, error) {
-	// generate cache key
-	cacheKey := util.GenerateCacheKey("post", id)
+// invalidatePosts clears the given posts and every cached post list
+func (ps *PostService) invalidatePosts(ctx context.Context, ids ...uint) error {
+	tags := []string{util.ListCacheTag("posts")}
+	for _, id := range ids {
+		tags = append(tags, util.CacheTag("post", id))
+	}
+
+	return ps.cache.InvalidateTags(ctx, tags...)
+}
 
-	// delete post from cache
-	if err := ps.cache.Delete(ctx, cacheKey); err != nil {
+func (ps *PostService) publishPostEvent(ctx context.Context, eventType domain.EventType, actorID uint, post *domain.Post) error {
+	event := domain.NewEvent(eventType, actorID, post.UserID)
+	event.PostID = post.ID
+
+	return ps.events.Publish(ctx, event)
+}
+
+func (ps *PostService) PublishScheduledPosts(ctx context.Context) ([]domain.Post, error) {
+	// publish posts whose publish time has passed, their events are stored in
+	// the same transaction
+	var posts []domain.Post
+	err := ps.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if posts, err = ps.repo.PublishDuePosts(ctx, time.Now()); err != nil {
+			return err
+		}
+
+		for i := range posts {
+			if err := ps.publishPostEvent(ctx, domain.EventPostPublished, 0, &posts[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// clear posts cache
-	if err := ps.cache.DeleteByPrefix(ctx, "posts:*"); err != nil {
+	if len(posts) == 0 {
+		return posts, nil
+	}
+
+	// clear the published posts and posts cache
+	ids := make([]uint, len(posts))
+	for i, post := range posts {
+		ids[i] = post.ID
+	}
+
+	if err := ps.invalidatePosts(ctx, ids...); err != nil {
 		return nil, err
 	}
 
-	// delete post from db
-	post, err := ps.repo.DeletePost(ctx, id)
+	// the posts are already published, a failing post doesn't stop the batch
+	for _, post := range posts {
+		if err := ps.invalidatePostTags(ctx, post.ID); err != nil {
+			slog.Warn("unable to invalidate tags of published post", "id", post.ID, "error", err)
+		}
+
+		// add to followers' feeds
+		if err := ps.feedSvc.FanOutPost(ctx, &post); err != nil {
+			slog.Warn("unable to fan out post", "id", post.ID, "error", err)
+		}
+	}
+
+	return posts, nil
+}
+
+// invalidatePostTags clears the tag listings of the post, returned rows don't include tags
+func (ps *PostService) invalidatePostTags(ctx context.Context, id uint) error {
+	post, err := ps.repo.GetPostByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return ps.tagSvc.InvalidateTags(ctx, post.Tags...)
+}
+
+func (ps *PostService) GetPostRevisions(ctx context.Context, postID uint) ([]domain.PostRevision, error) {
+	return ps.revisionRepo.GetRevisions(ctx, postID)
+}
+
+func (ps *PostService) GetPostRevisionDiff(ctx context.Context, postID, fromID, toID uint) (*domain.PostRevisionDiff, error) {
+	from, err := ps.revisionRepo.GetRevisionByID(ctx, postID, fromID)
 	if err != nil {
 		return nil, err
 	}
 
-	return post, nil
+	to, err := ps.revisionRepo.GetRevisionByID(ctx, postID, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := util.DiffRevisions(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PostRevisionDiff{
+		From: from.ID,
+		To:   to.ID,
+		Diff: diff,
+	}, nil
+}
+
+func (ps *PostService) RestorePostRevision(ctx context.Context, userID, postID, revisionID uint, ifMatch domain.IfMatch) (*domain.Post, error) {
+	revision, err := ps.revisionRepo.GetRevisionByID(ctx, postID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// restoring is an update, so it is stored as a new revision
+	return ps.PatchPost(ctx, userID, postID, ifMatch, &domain.PostPatch{
+		Title:   &revision.Title,
+		Content: &revision.Content,
+	})
+}
+
+func (ps *PostService) createRevision(ctx context.Context, userID uint, post *domain.Post) error {
+	_, err := ps.revisionRepo.CreateRevision(ctx, &domain.PostRevision{
+		PostID:  post.ID,
+		UserID:  userID,
+		Title:   post.Title,
+		Content: post.Content,
+	})
+
+	return err
+}
+
+func getTagNames(tags []domain.Tag) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+
+	return names
+}
+
+func postCacheID(post domain.Post) any {
+	return post.ID
 }
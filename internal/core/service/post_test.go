@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/mocks"
+	"gorm.io/gorm"
+)
+
+// failingFeedService fails every fan-out, recording the posts
+type failingFeedService struct {
+	port.FeedService
+	fannedOut []uint
+}
+
+func (fs *failingFeedService) FanOutPost(ctx context.Context, post *domain.Post) error {
+	fs.fannedOut = append(fs.fannedOut, post.ID)
+	return errors.New("feed unavailable")
+}
+
+// noopTagService ignores tag invalidations
+type noopTagService struct {
+	port.TagService
+}
+
+func (noopTagService) InvalidateTags(ctx context.Context, tags ...domain.Tag) error {
+	return nil
+}
+
+func TestPostService_PublishScheduledPosts(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	posts := []domain.Post{
+		{Model: gorm.Model{ID: 1}, Published: true, PublishedAt: &now},
+		{Model: gorm.Model{ID: 2}, Published: true, PublishedAt: &now},
+		{Model: gorm.Model{ID: 3}, Published: true, PublishedAt: &now},
+	}
+
+	repo := mocks.NewPostRepository(t)
+	repo.On("PublishDuePosts", ctx, mock.Anything).Return(posts, nil)
+	repo.On("GetPostByID", ctx, uint(1)).Return(&posts[0], nil)
+	repo.On("GetPostByID", ctx, uint(2)).Return(nil, errors.New("connection reset"))
+	repo.On("GetPostByID", ctx, uint(3)).Return(&posts[2], nil)
+
+	cache := mocks.NewCacheRepository(t)
+	cache.On("InvalidateTags", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// every published post has an event
+	events := mocks.NewEventBus(t)
+	events.On("Publish", ctx, mock.MatchedBy(func(event domain.Event) bool {
+		return event.Type == domain.EventPostPublished
+	})).Return(nil).Times(3)
+
+	feedSvc := &failingFeedService{}
+	postService := NewPostService(repo, nil, nil, nil, noopTagService{}, nil, feedSvc, fakeTransactor{}, events, cache, nil)
+
+	// failures of one post don't stop the batch, the posts are already published
+	published, err := postService.PublishScheduledPosts(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, published, 3)
+	assert.Equal(t, []uint{1, 2, 3}, feedSvc.fannedOut)
+}
+
+// fakeSlugService keeps the current slug, or derives it from the title
+type fakeSlugService struct {
+	port.SlugService
+}
+
+func (fakeSlugService) GenerateSlug(ctx context.Context, postID uint, currentSlug, title string) (string, error) {
+	if currentSlug != "" {
+		return currentSlug, nil
+	}
+
+	return strings.ToLower(title), nil
+}
+
+func (fakeSlugService) ChangeSlug(ctx context.Context, postID uint, oldSlug, newSlug string) error {
+	return nil
+}
+
+func TestPostService_PatchPost_Unpublish(t *testing.T) {
+	ctx := context.Background()
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	testCases := []struct {
+		desc              string
+		patch             *domain.PostPatch
+		expectedPublishAt *time.Time
+	}{
+		{
+			desc:              "Success_ClearsPassedSchedule",
+			patch:             &domain.PostPatch{Published: new(bool)},
+			expectedPublishAt: nil,
+		},
+		{
+			desc:              "Success_Reschedules",
+			patch:             &domain.PostPatch{Published: new(bool), PublishAt: &future},
+			expectedPublishAt: &future,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// a scheduled post published by the scheduler
+			post := &domain.Post{
+				Model:       gorm.Model{ID: 1},
+				Title:       "Hello",
+				Content:     "World",
+				CategoryID:  1,
+				Published:   true,
+				PublishAt:   &past,
+				PublishedAt: &past,
+				Slug:        "hello",
+				Version:     2,
+			}
+
+			repo := mocks.NewPostRepository(t)
+			repo.On("GetPostByID", ctx, uint(1)).Return(post, nil)
+			repo.On("UpdatePost", ctx, post).Return(post, nil)
+
+			revisionRepo := mocks.NewPostRevisionRepository(t)
+			revisionRepo.On("CreateRevision", ctx, mock.Anything).Return(&domain.PostRevision{}, nil)
+
+			cache := mocks.NewCacheRepository(t)
+			cache.On("InvalidateTags", ctx, mock.Anything, mock.Anything).Return(nil)
+			cache.On("SetWithTags", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			postService := NewPostService(repo, revisionRepo, nil, fakeSlugService{}, noopTagService{}, nil, nil, fakeTransactor{}, newEventBusMock(), cache, nil)
+
+			patched, err := postService.PatchPost(ctx, 1, 1, domain.MatchVersion(2), tc.patch)
+			assert.NoError(t, err)
+			assert.False(t, patched.Published)
+			assert.Nil(t, patched.PublishedAt)
+			assert.Equal(t, tc.expectedPublishAt, patched.PublishAt)
+		})
+	}
+}
+
+func TestPostService_GetPostRevisionDiff(t *testing.T) {
+	ctx := context.Background()
+
+	from := &domain.PostRevision{ID: 1, PostID: 1, Title: "Hello", Content: "World"}
+	to := &domain.PostRevision{ID: 2, PostID: 1, Title: "Hello", Content: "Gophers"}
+
+	testCases := []struct {
+		desc  string
+		mocks func(revisionRepo *mocks.PostRevisionRepository)
+		diff  []string
+		err   error
+	}{
+		{
+			desc: "Success",
+			mocks: func(revisionRepo *mocks.PostRevisionRepository) {
+				revisionRepo.On("GetRevisionByID", ctx, uint(1), uint(1)).Return(from, nil)
+				revisionRepo.On("GetRevisionByID", ctx, uint(1), uint(2)).Return(to, nil)
+			},
+			diff: []string{"--- revision/1", "+++ revision/2", "-World", "+Gophers"},
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(revisionRepo *mocks.PostRevisionRepository) {
+				revisionRepo.On("GetRevisionByID", ctx, uint(1), uint(1)).Return(from, nil)
+				revisionRepo.On("GetRevisionByID", ctx, uint(1), uint(2)).Return(nil, domain.ErrNotFound)
+			},
+			err: domain.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			revisionRepo := mocks.NewPostRevisionRepository(t)
+			tc.mocks(revisionRepo)
+
+			postService := NewPostService(nil, revisionRepo, nil, nil, nil, nil, nil, fakeTransactor{}, newEventBusMock(), nil, nil)
+
+			diff, err := postService.GetPostRevisionDiff(ctx, 1, 1, 2)
+			assert.Equal(t, tc.err, err)
+			if tc.err != nil {
+				return
+			}
+
+			assert.Equal(t, uint(1), diff.From)
+			assert.Equal(t, uint(2), diff.To)
+			for _, line := range tc.diff {
+				assert.Contains(t, diff.Diff, line)
+			}
+		})
+	}
+}
+
+func TestPostService_RestorePostRevision(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		desc    string
+		version uint
+		mocks   func(repo *mocks.PostRepository, revisionRepo *mocks.PostRevisionRepository, cache *mocks.CacheRepository)
+		err     error
+	}{
+		{
+			desc:    "Success",
+			version: 2,
+			mocks: func(repo *mocks.PostRepository, revisionRepo *mocks.PostRevisionRepository, cache *mocks.CacheRepository) {
+				repo.On("UpdatePost", ctx, mock.Anything).Return(nil, nil)
+
+				// restoring is stored as a new revision
+				revisionRepo.On("CreateRevision", ctx, mock.MatchedBy(func(revision *domain.PostRevision) bool {
+					return revision.Title == "Hello" && revision.Content == "World" && revision.UserID == 3
+				})).Return(&domain.PostRevision{ID: 3}, nil)
+
+				cache.On("InvalidateTags", ctx, mock.Anything, mock.Anything).Return(nil)
+				cache.On("SetWithTags", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			desc:    "Fail_OutdatedVersion",
+			version: 1,
+			mocks: func(repo *mocks.PostRepository, revisionRepo *mocks.PostRevisionRepository, cache *mocks.CacheRepository) {
+			},
+			err: domain.ErrPreconditionFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			post := &domain.Post{
+				Model:      gorm.Model{ID: 1},
+				Title:      "Hi",
+				Content:    "Everyone",
+				CategoryID: 1,
+				Slug:       "hi",
+				Version:    2,
+			}
+
+			repo := mocks.NewPostRepository(t)
+			repo.On("GetPostByID", ctx, uint(1)).Return(post, nil)
+
+			revisionRepo := mocks.NewPostRevisionRepository(t)
+			revisionRepo.On("GetRevisionByID", ctx, uint(1), uint(1)).Return(&domain.PostRevision{ID: 1, PostID: 1, Title: "Hello", Content: "World"}, nil)
+
+			cache := mocks.NewCacheRepository(t)
+			tc.mocks(repo, revisionRepo, cache)
+
+			postService := NewPostService(repo, revisionRepo, nil, fakeSlugService{}, noopTagService{}, nil, nil, fakeTransactor{}, newEventBusMock(), cache, nil)
+
+			restored, err := postService.RestorePostRevision(ctx, 3, 1, 1, domain.MatchVersion(tc.version))
+			assert.Equal(t, tc.err, err)
+			if tc.err != nil {
+				return
+			}
+
+			assert.Equal(t, "Hello", restored.Title)
+			assert.Equal(t, "World", restored.Content)
+		})
+	}
+}
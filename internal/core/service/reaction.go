@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/util"
+)
+
+const (
+	// set of post ids whose counters changed since the last reconcile
+	reactionsDirtyKey = "reactions:dirty"
+	// number of posts reconciled per batch
+	reconcileBatchSize = 100
+)
+
+type ReactionService struct {
+	repo     port.ReactionRepository
+	postRepo port.PostRepository
+	cache    port.CacheRepository
+}
+
+func NewReactionService(repo port.ReactionRepository, postRepo port.PostRepository, cache port.CacheRepository) *ReactionService {
+	return &ReactionService{
+		repo,
+		postRepo,
+		cache,
+	}
+}
+
+func (rs *ReactionService) ToggleReaction(ctx context.Context, userID, postID uint, reactionType domain.ReactionType) (*domain.ReactionSummary, error) {
+	if !reactionType.Valid() {
+		return nil, domain.ErrBadRequest
+	}
+
+	// check post exists, other users' drafts are hidden
+	post, err := rs.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !post.Published && post.UserID != userID {
+		return nil, domain.ErrNotFound
+	}
+
+	// load the counters before changing them
+	if _, err := rs.GetReactionCounts(ctx, postID); err != nil {
+		return nil, err
+	}
+
+	summary := &domain.ReactionSummary{PostID: postID}
+
+	reaction, err := rs.repo.GetReaction(ctx, postID, userID)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		// add reaction
+		if _, err := rs.repo.CreateReaction(ctx, &domain.Reaction{
+			PostID: postID,
+			UserID: userID,
+			Type:   reactionType,
+		}); err != nil {
+			return nil, err
+		}
+
+		if err := rs.incrCount(ctx, postID, reactionType, 1); err != nil {
+			return nil, err
+		}
+		summary.Reaction = &reactionType
+	case err != nil:
+		return nil, err
+	case reaction.Type == reactionType:
+		// remove reaction
+		if err := rs.repo.DeleteReaction(ctx, reaction.ID); err != nil {
+			return nil, err
+		}
+
+		if err := rs.incrCount(ctx, postID, reactionType, -1); err != nil {
+			return nil, err
+		}
+	default:
+		// change reaction type
+		oldType := reaction.Type
+		reaction.Type = reactionType
+		if _, err := rs.repo.UpdateReaction(ctx, reaction); err != nil {
+			return nil, err
+		}
+
+		if err := rs.incrCount(ctx, postID, oldType, -1); err != nil {
+			return nil, err
+		}
+		if err := rs.incrCount(ctx, postID, reactionType, 1); err != nil {
+			return nil, err
+		}
+		summary.Reaction = &reactionType
+	}
+
+	// mark post for reconciliation
+	if err := rs.markDirty(ctx, postID); err != nil {
+		return nil, err
+	}
+
+	counts, err := rs.GetReactionCounts(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	summary.Counts = counts[postID]
+
+	return summary, nil
+}
+
+// GetPostReactions returns the reaction counts of an existing post
+func (rs *ReactionService) GetPostReactions(ctx context.Context, postID uint) (domain.ReactionCounts, error) {
+	if _, err := rs.postRepo.GetPostByID(ctx, postID); err != nil {
+		return nil, domain.ErrNotFound
+	}
+
+	counts, err := rs.GetReactionCounts(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	return counts[postID], nil
+}
+
+// GetReactionCounts reads the counters from the cache, loading missing ones from the persisted counts
+func (rs *ReactionService) GetReactionCounts(ctx context.Context, postIDs ...uint) (map[uint]domain.ReactionCounts, error) {
+	res := map[uint]domain.ReactionCounts{}
+	if len(postIDs) == 0 {
+		return res, nil
+	}
+
+	keys := make([]string, len(postIDs))
+	for i, postID := range postIDs {
+		keys[i] = reactionsCacheKey(postID)
+	}
+
+	hashes, err := rs.cache.HGetAllMulti(ctx, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []uint
+	for i, postID := range postIDs {
+		if len(hashes[i]) == 0 {
+			missing = append(missing, postID)
+			continue
+		}
+
+		counts, err := parseReactionCounts(hashes[i])
+		if err != nil {
+			return nil, err
+		}
+		res[postID] = counts
+	}
+
+	if len(missing) == 0 {
+		return res, nil
+	}
+
+	// get persisted counts from db
+	persisted, err := rs.repo.GetReactionCounts(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	// concurrent loads only set the fields once, so they can't add up
+	loaded := groupReactionCounts(missing, persisted)
+	for postID, counts := range loaded {
+		if err := rs.cache.HSetNX(ctx, reactionsCacheKey(postID), counterFields(counts)); err != nil {
+			return nil, err
+		}
+
+		res[postID] = counts
+	}
+
+	return res, nil
+}
+
+// ReconcileReactionCounts recounts the reactions of the changed posts, fixing drifted
+// counters and persisting the counts to the db
+func (rs *ReactionService) ReconcileReactionCounts(ctx context.Context) (int, error) {
+	reconciled := 0
+
+	for {
+		members, err := rs.cache.SPop(ctx, reactionsDirtyKey, reconcileBatchSize)
+		if err != nil {
+			return reconciled, err
+		}
+
+		if len(members) == 0 {
+			return reconciled, nil
+		}
+
+		postIDs := make([]uint, 0, len(members))
+		for _, member := range members {
+			id, err := strconv.ParseUint(member, 10, 64)
+			if err != nil {
+				continue
+			}
+			postIDs = append(postIDs, uint(id))
+		}
+
+		if err := rs.reconcile(ctx, postIDs); err != nil {
+			// the posts were popped, mark them again so the next run retries them
+			return reconciled, errors.Join(err, rs.cache.SAdd(context.WithoutCancel(ctx), reactionsDirtyKey, members...))
+		}
+
+		reconciled += len(postIDs)
+	}
+}
+
+func (rs *ReactionService) reconcile(ctx context.Context, postIDs []uint) error {
+	counted, err := rs.repo.CountReactions(ctx, postIDs)
+	if err != nil {
+		return err
+	}
+
+	if err := rs.repo.SaveReactionCounts(ctx, postIDs, counted); err != nil {
+		return err
+	}
+
+	// reset counters, a toggle racing with the reset marks the post again
+	// and is fixed by the next run
+	for postID, counts := range groupReactionCounts(postIDs, counted) {
+		if err := rs.cache.HReplace(ctx, reactionsCacheKey(postID), counterFields(counts)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (rs *ReactionService) incrCount(ctx context.Context, postID uint, reactionType domain.ReactionType, incr int64) error {
+	_, err := rs.cache.HIncrBy(ctx, reactionsCacheKey(postID), string(reactionType), incr)
+	return err
+}
+
+func (rs *ReactionService) markDirty(ctx context.Context, postID uint) error {
+	return rs.cache.SAdd(ctx, reactionsDirtyKey, strconv.FormatUint(uint64(postID), 10))
+}
+
+func reactionsCacheKey(postID uint) string {
+	return util.GenerateCacheKey("reactions", postID)
+}
+
+func parseReactionCounts(fields map[string]string) (domain.ReactionCounts, error) {
+	counts := domain.ReactionCounts{}
+	for field, val := range fields {
+		count, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		counts[domain.ReactionType(field)] = count
+	}
+
+	return counts, nil
+}
+
+// counterFields writes every reaction type so a loaded post with no reactions isn't loaded again
+func counterFields(counts domain.ReactionCounts) map[string]int64 {
+	fields := make(map[string]int64, len(counts))
+	for reactionType, count := range counts {
+		fields[string(reactionType)] = count
+	}
+
+	return fields
+}
+
+// groups counts by post, every post gets all reaction types
+func groupReactionCounts(postIDs []uint, counts []domain.PostReactionCount) map[uint]domain.ReactionCounts {
+	res := map[uint]domain.ReactionCounts{}
+	for _, postID := range postIDs {
+		res[postID] = domain.ReactionCounts{}
+		for _, reactionType := range domain.ReactionTypes {
+			res[postID][reactionType] = 0
+		}
+	}
+
+	for _, count := range counts {
+		res[count.PostID][count.Type] = count.Count
+	}
+
+	return res
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/mocks"
+)
+
+func TestReactionService_GetReactionCounts(t *testing.T) {
+	ctx := context.Background()
+
+	repo := mocks.NewReactionRepository(t)
+	repo.On("GetReactionCounts", ctx, []uint{2}).Return([]domain.PostReactionCount{
+		{PostID: 2, Type: domain.ReactionLove, Count: 4},
+	}, nil)
+
+	// cached counters are read in one round trip, missing ones are loaded
+	// without overwriting concurrent loads and without marking the post dirty
+	cache := mocks.NewCacheRepository(t)
+	cache.On("HGetAllMulti", ctx, reactionsCacheKey(1), reactionsCacheKey(2)).Return([]map[string]string{
+		{"like": "3"},
+		{},
+	}, nil)
+	cache.On("HSetNX", ctx, reactionsCacheKey(2), map[string]int64{
+		"like": 0, "love": 4, "laugh": 0, "wow": 0, "sad": 0, "angry": 0,
+	}).Return(nil)
+
+	reactionService := NewReactionService(repo, nil, cache)
+
+	counts, err := reactionService.GetReactionCounts(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), counts[1][domain.ReactionLike])
+	assert.Equal(t, int64(4), counts[2][domain.ReactionLove])
+	assert.Equal(t, int64(0), counts[2][domain.ReactionLike])
+}
+
+func TestReactionService_ToggleReaction(t *testing.T) {
+	ctx := context.Background()
+	counts := map[string]string{"like": "1"}
+
+	testCases := []struct {
+		desc  string
+		post  *domain.Post
+		mocks func(repo *mocks.ReactionRepository, cache *mocks.CacheRepository)
+		err   error
+	}{
+		{
+			desc: "Success",
+			post: &domain.Post{UserID: 4, Published: true},
+			mocks: func(repo *mocks.ReactionRepository, cache *mocks.CacheRepository) {
+				cache.On("HGetAllMulti", ctx, reactionsCacheKey(2)).Return([]map[string]string{counts}, nil)
+				repo.On("GetReaction", ctx, uint(2), uint(3)).Return(nil, domain.ErrNotFound)
+				repo.On("CreateReaction", ctx, mock.Anything).Return(&domain.Reaction{}, nil)
+				cache.On("HIncrBy", ctx, reactionsCacheKey(2), "like", int64(1)).Return(int64(1), nil)
+				cache.On("SAdd", ctx, reactionsDirtyKey, "2").Return(nil)
+			},
+		},
+		{
+			desc: "Success_OwnDraft",
+			post: &domain.Post{UserID: 3, Published: false},
+			mocks: func(repo *mocks.ReactionRepository, cache *mocks.CacheRepository) {
+				cache.On("HGetAllMulti", ctx, reactionsCacheKey(2)).Return([]map[string]string{counts}, nil)
+				repo.On("GetReaction", ctx, uint(2), uint(3)).Return(nil, domain.ErrNotFound)
+				repo.On("CreateReaction", ctx, mock.Anything).Return(&domain.Reaction{}, nil)
+				cache.On("HIncrBy", ctx, reactionsCacheKey(2), "like", int64(1)).Return(int64(1), nil)
+				cache.On("SAdd", ctx, reactionsDirtyKey, "2").Return(nil)
+			},
+		},
+		{
+			desc:  "Fail_UnpublishedPost",
+			post:  &domain.Post{UserID: 4, Published: false},
+			mocks: func(repo *mocks.ReactionRepository, cache *mocks.CacheRepository) {},
+			err:   domain.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			postRepo := mocks.NewPostRepository(t)
+			postRepo.On("GetPostByID", ctx, uint(2)).Return(tc.post, nil)
+
+			repo := mocks.NewReactionRepository(t)
+			cache := mocks.NewCacheRepository(t)
+			tc.mocks(repo, cache)
+
+			reactionService := NewReactionService(repo, postRepo, cache)
+
+			summary, err := reactionService.ToggleReaction(ctx, 3, 2, domain.ReactionLike)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				assert.Equal(t, domain.ReactionLike, *summary.Reaction)
+			}
+		})
+	}
+}
+
+func TestReactionService_GetPostReactions_NotFound(t *testing.T) {
+	ctx := context.Background()
+
+	postRepo := mocks.NewPostRepository(t)
+	postRepo.On("GetPostByID", ctx, uint(1)).Return(nil, domain.ErrNotFound)
+
+	reactionService := NewReactionService(nil, postRepo, nil)
+
+	_, err := reactionService.GetPostReactions(ctx, 1)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestReactionService_ReconcileReactionCounts(t *testing.T) {
+	ctx := context.Background()
+	counted := []domain.PostReactionCount{{PostID: 1, Type: domain.ReactionLike, Count: 2}}
+
+	testCases := []struct {
+		desc       string
+		mocks      func(repo *mocks.ReactionRepository, cache *mocks.CacheRepository)
+		reconciled int
+		err        bool
+	}{
+		{
+			desc: "Success",
+			mocks: func(repo *mocks.ReactionRepository, cache *mocks.CacheRepository) {
+				cache.On("SPop", ctx, reactionsDirtyKey, int64(reconcileBatchSize)).Return([]string{}, nil).Once()
+				repo.On("CountReactions", ctx, []uint{1, 2}).Return(counted, nil)
+				repo.On("SaveReactionCounts", ctx, []uint{1, 2}, counted).Return(nil)
+				cache.On("HReplace", ctx, mock.Anything, mock.Anything).Return(nil).Twice()
+			},
+			reconciled: 2,
+		},
+		{
+			desc: "Fail_CountReactions",
+			mocks: func(repo *mocks.ReactionRepository, cache *mocks.CacheRepository) {
+				repo.On("CountReactions", ctx, []uint{1, 2}).Return(nil, errors.New("connection reset"))
+				cache.On("SAdd", mock.Anything, reactionsDirtyKey, "1", "2").Return(nil)
+			},
+			err: true,
+		},
+		{
+			desc: "Fail_SaveReactionCounts",
+			mocks: func(repo *mocks.ReactionRepository, cache *mocks.CacheRepository) {
+				repo.On("CountReactions", ctx, []uint{1, 2}).Return(counted, nil)
+				repo.On("SaveReactionCounts", ctx, []uint{1, 2}, counted).Return(errors.New("connection reset"))
+				cache.On("SAdd", mock.Anything, reactionsDirtyKey, "1", "2").Return(nil)
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := mocks.NewReactionRepository(t)
+			cache := mocks.NewCacheRepository(t)
+			cache.On("SPop", ctx, reactionsDirtyKey, int64(reconcileBatchSize)).Return([]string{"1", "2"}, nil).Once()
+			tc.mocks(repo, cache)
+
+			// popped posts that failed are marked dirty again
+			reactionService := NewReactionService(repo, nil, cache)
+			reconciled, err := reactionService.ReconcileReactionCounts(ctx)
+			assert.Equal(t, tc.err, err != nil)
+			assert.Equal(t, tc.reconciled, reconciled)
+		})
+	}
+}
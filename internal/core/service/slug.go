@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/util"
+)
+
+// fallback for titles without any transliterable characters
+const defaultSlug = "post"
+
+type SlugService struct {
+	repo port.SlugRepository
+}
+
+func NewSlugService(repo port.SlugRepository) *SlugService {
+	return &SlugService{
+		repo,
+	}
+}
+
+// GenerateSlug returns a unique slug for the title, appending a numeric
+// suffix on collision. The bare slug is preferred when it's free, otherwise
+// the current slug is kept if the title still maps to it.
+func (ss *SlugService) GenerateSlug(ctx context.Context, postID uint, currentSlug, title string) (string, error) {
+	base := util.Slugify(title)
+	if base == "" {
+		base = defaultSlug
+	}
+
+	if currentSlug == base {
+		return currentSlug, nil
+	}
+
+	taken, err := ss.repo.GetTakenSlugs(ctx, base, postID)
+	if err != nil {
+		return "", err
+	}
+
+	// the post's own slug doesn't collide with itself
+	takenSet := make(map[string]struct{}, len(taken))
+	for _, slug := range taken {
+		if slug != currentSlug {
+			takenSet[slug] = struct{}{}
+		}
+	}
+
+	if _, ok := takenSet[base]; !ok {
+		return base, nil
+	}
+
+	// keep slug stable if only the suffix differs
+	if currentSlug != "" && slugBase(currentSlug) == base {
+		return currentSlug, nil
+	}
+
+	for n := 2; ; n++ {
+		slug := base + "-" + strconv.Itoa(n)
+		if _, ok := takenSet[slug]; !ok {
+			return slug, nil
+		}
+	}
+}
+
+// ChangeSlug keeps the old slug as a redirect to the post
+func (ss *SlugService) ChangeSlug(ctx context.Context, postID uint, oldSlug, newSlug string) error {
+	if oldSlug == newSlug {
+		return nil
+	}
+
+	// the post may be taking back one of its previous slugs
+	if err := ss.repo.DeleteSlugRedirect(ctx, postID, newSlug); err != nil {
+		return err
+	}
+
+	_, err := ss.repo.CreateSlugRedirect(ctx, &domain.PostSlug{
+		PostID: postID,
+		Slug:   oldSlug,
+	})
+
+	return err
+}
+
+// ResolveSlug returns the id of the post owning the current or a previous slug
+func (ss *SlugService) ResolveSlug(ctx context.Context, slug string) (uint, error) {
+	return ss.repo.GetPostIDBySlug(ctx, slug)
+}
+
+// strips the numeric suffix added on collision
+func slugBase(slug string) string {
+	i := strings.LastIndexByte(slug, '-')
+	if i < 0 {
+		return slug
+	}
+
+	if _, err := strconv.Atoi(slug[i+1:]); err != nil {
+		return slug
+	}
+
+	return slug[:i]
+}
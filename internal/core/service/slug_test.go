@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/mocks"
+)
+
+func TestSlugService_GenerateSlug(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		desc        string
+		currentSlug string
+		taken       []string
+		expected    string
+	}{
+		{
+			desc:     "new post with a free base",
+			taken:    nil,
+			expected: "hello-world",
+		},
+		{
+			desc:     "new post with a taken base",
+			taken:    []string{"hello-world", "hello-world-2"},
+			expected: "hello-world-3",
+		},
+		{
+			desc:        "renamed back to a free base",
+			currentSlug: "hello-world-2",
+			taken:       []string{"hello-world-2"},
+			expected:    "hello-world",
+		},
+		{
+			desc:        "suffixed slug kept while the base is taken",
+			currentSlug: "hello-world-3",
+			taken:       []string{"hello-world", "hello-world-3"},
+			expected:    "hello-world-3",
+		},
+		{
+			desc:        "renamed to a taken base",
+			currentSlug: "old-title",
+			taken:       []string{"hello-world"},
+			expected:    "hello-world-2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := mocks.NewSlugRepository(t)
+			repo.On("GetTakenSlugs", ctx, "hello-world", uint(1)).Return(tc.taken, nil)
+
+			slugService := NewSlugService(repo)
+
+			slug, err := slugService.GenerateSlug(ctx, 1, tc.currentSlug, "Hello World")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, slug)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+)
+
+type StreamService struct {
+	broker port.Broker
+}
+
+func NewStreamService(broker port.Broker) *StreamService {
+	return &StreamService{
+		broker,
+	}
+}
+
+func (ss *StreamService) Send(ctx context.Context, userID uint, msgType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return ss.broker.Publish(ctx, &domain.StreamMessage{
+		Type:   msgType,
+		UserID: userID,
+		Data:   data,
+	})
+}
+
+func (ss *StreamService) Subscribe(ctx context.Context, userID uint, lastID uint64) (<-chan domain.StreamMessage, error) {
+	// subscribe before reading the history so nothing is missed in between
+	live, err := ss.broker.Subscribe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var missed []domain.StreamMessage
+	if lastID > 0 {
+		missed, err = ss.broker.Since(ctx, lastID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	msgs := make(chan domain.StreamMessage)
+	go func() {
+		defer close(msgs)
+
+		send := func(msg domain.StreamMessage) bool {
+			// skip other users' messages
+			if msg.UserID != 0 && msg.UserID != userID {
+				return true
+			}
+
+			select {
+			case msgs <- msg:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for _, msg := range missed {
+			if !send(msg) {
+				return
+			}
+			lastID = max(lastID, msg.ID)
+		}
+
+		// skip live messages already replayed
+		for msg := range live {
+			if msg.ID <= lastID {
+				continue
+			}
+
+			if !send(msg) {
+				return
+			}
+		}
+	}()
+
+	return msgs, nil
+}
+
+func (ss *StreamService) HandleEvent(ctx context.Context, event domain.Event) error {
+	if !strings.HasPrefix(string(event.Type), "post.") {
+		return nil
+	}
+
+	return ss.Send(ctx, 0, string(event.Type), event)
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/util"
+)
+
+const maxTagLength = 50
+
+// tags are cached as a single list
+const allTags = "all"
+
+type TagService struct {
+	repo       port.TagRepository
+	cache      port.CacheRepository
+	tagsCache  *util.CacheAside[[]domain.TagWithCount]
+	postsCache *util.CacheAside[[]domain.Post]
+}
+
+func NewTagService(repo port.TagRepository, cache port.CacheRepository, policy *util.CachePolicy) *TagService {
+	return &TagService{
+		repo,
+		cache,
+		util.NewCacheAside(cache, "tags", policy, util.ListCacheTags("tags", "tag", tagCacheID)),
+		util.NewCacheAside(cache, "tag", policy, tagPostsCacheTags),
+	}
+}
+
+// ResolveTags returns the tags with the given names, creating the missing ones
+func (ts *TagService) ResolveTags(ctx context.Context, names []string) ([]domain.Tag, error) {
+	tags := []domain.Tag{}
+	seen := map[string]struct{}{}
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		slug := util.Slugify(name)
+		if slug == "" || len(name) > maxTagLength || len(slug) > maxTagLength {
+			return nil, domain.ErrBadRequest
+		}
+
+		// skip tags differing only by case or punctuation
+		if _, ok := seen[slug]; ok {
+			continue
+		}
+		seen[slug] = struct{}{}
+
+		tags = append(tags, domain.Tag{
+			Name: name,
+			Slug: slug,
+		})
+	}
+
+	return ts.repo.GetOrCreateTags(ctx, tags)
+}
+
+// SetPostTags replaces the tags of the post with the given names, returning
+// the changed tags
+func (ts *TagService) SetPostTags(ctx context.Context, post *domain.Post, names []string) ([]domain.Tag, error) {
+	tags, err := ts.ResolveTags(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ts.repo.ReplacePostTags(ctx, post, tags); err != nil {
+		return nil, err
+	}
+
+	// both the previous and the new tags changed
+	changed := slices.Concat(post.Tags, tags)
+	post.Tags = tags
+
+	return changed, nil
+}
+
+func (ts *TagService) GetTags(ctx context.Context) ([]domain.TagWithCount, error) {
+	return ts.tagsCache.Get(ctx, allTags, ts.repo.GetTagsWithCount)
+}
+
+func (ts *TagService) GetPostsByTag(ctx context.Context, slug string, start, end uint64) ([]domain.Post, error) {
+	return ts.postsCache.Get(ctx, tagPostsParam{slug, start, end}, func(ctx context.Context) ([]domain.Post, error) {
+		tag, err := ts.repo.GetTagBySlug(ctx, slug)
+		if err != nil {
+			return nil, err
+		}
+
+		return ts.repo.GetPostsByTag(ctx, tag.ID, start, end)
+	})
+}
+
+// InvalidateTags clears the usage counts and the post lists of the given tags
+func (ts *TagService) InvalidateTags(ctx context.Context, tags ...domain.Tag) error {
+	cacheTags := []string{util.ListCacheTag("tags")}
+	for _, tag := range tags {
+		cacheTags = append(cacheTags, util.CacheTag("tag", tag.Slug))
+	}
+
+	return ts.cache.InvalidateTags(ctx, cacheTags...)
+}
+
+func tagCacheID(tag domain.TagWithCount) any {
+	return tag.Slug
+}
+
+// tagPostsParam identifies a cached page of a tag's posts
+type tagPostsParam struct {
+	slug       string
+	start, end uint64
+}
+
+func (p tagPostsParam) String() string {
+	return p.slug + ":posts:" + util.GenerateCacheKeyParams(p.start, p.end)
+}
+
+// tagPostsCacheTags tags the pages with the tag and with their posts, so
+// changing a post clears the pages containing it
+func tagPostsCacheTags(param any, posts []domain.Post) []string {
+	tags := []string{util.CacheTag("tag", param.(tagPostsParam).slug)}
+	for _, post := range posts {
+		tags = append(tags, util.CacheTag("post", post.ID))
+	}
+
+	return tags
+}
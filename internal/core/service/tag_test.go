@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/mocks"
+	"gorm.io/gorm"
+)
+
+func TestTagService_ResolveTags(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		desc     string
+		names    []string
+		expected []domain.Tag
+		err      error
+	}{
+		{
+			desc:  "Success_Normalised",
+			names: []string{"  Go ", "Web Development", "Café"},
+			expected: []domain.Tag{
+				{Name: "Go", Slug: "go"},
+				{Name: "Web Development", Slug: "web-development"},
+				{Name: "Café", Slug: "cafe"},
+			},
+		},
+		{
+			desc:  "Success_Deduplicated",
+			names: []string{"Go", "go", " GO! ", "golang"},
+			expected: []domain.Tag{
+				{Name: "Go", Slug: "go"},
+				{Name: "golang", Slug: "golang"},
+			},
+		},
+		{
+			desc:     "Success_Empty",
+			names:    nil,
+			expected: []domain.Tag{},
+		},
+		{
+			desc:  "Fail_Blank",
+			names: []string{"Go", "  "},
+			err:   domain.ErrBadRequest,
+		},
+		{
+			desc:  "Fail_Punctuation",
+			names: []string{"!!!"},
+			err:   domain.ErrBadRequest,
+		},
+		{
+			desc:  "Fail_TooLong",
+			names: []string{strings.Repeat("a", maxTagLength+1)},
+			err:   domain.ErrBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := mocks.NewTagRepository(t)
+			if tc.err == nil {
+				repo.On("GetOrCreateTags", ctx, tc.expected).Return(tc.expected, nil)
+			}
+
+			tagService := NewTagService(repo, nil, nil)
+
+			tags, err := tagService.ResolveTags(ctx, tc.names)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.expected, tags)
+		})
+	}
+}
+
+func TestTagService_SetPostTags(t *testing.T) {
+	ctx := context.Background()
+
+	previous := []domain.Tag{{Model: gorm.Model{ID: 1}, Name: "Go", Slug: "go"}}
+	post := &domain.Post{Model: gorm.Model{ID: 1}, Tags: previous}
+
+	repo := mocks.NewTagRepository(t)
+	repo.On("GetOrCreateTags", ctx, mock.Anything).Return([]domain.Tag{{Model: gorm.Model{ID: 2}, Name: "Rust", Slug: "rust"}}, nil)
+	repo.On("ReplacePostTags", ctx, post, []domain.Tag{{Model: gorm.Model{ID: 2}, Name: "Rust", Slug: "rust"}}).Return(nil)
+
+	tagService := NewTagService(repo, nil, nil)
+
+	// both the removed and the added tags are returned for invalidation
+	changed, err := tagService.SetPostTags(ctx, post, []string{"Rust"})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Tag{{Model: gorm.Model{ID: 1}, Name: "Go", Slug: "go"}, {Model: gorm.Model{ID: 2}, Name: "Rust", Slug: "rust"}}, changed)
+	assert.Equal(t, []domain.Tag{{Model: gorm.Model{ID: 2}, Name: "Rust", Slug: "rust"}}, post.Tags)
+}
+
+func TestTagService_GetPostsByTag(t *testing.T) {
+	ctx := context.Background()
+	posts := []domain.Post{{Model: gorm.Model{ID: 1}, Title: "Hello"}}
+
+	testCases := []struct {
+		desc  string
+		mocks func(repo *mocks.TagRepository, cache *mocks.CacheRepository)
+		posts []domain.Post
+		err   error
+	}{
+		{
+			desc: "Success",
+			mocks: func(repo *mocks.TagRepository, cache *mocks.CacheRepository) {
+				cache.On("Get", ctx, "tag:go:posts:0-9").Return(nil, domain.ErrNotFound)
+				repo.On("GetTagBySlug", mock.Anything, "go").Return(&domain.Tag{Model: gorm.Model{ID: 1}, Slug: "go"}, nil)
+				repo.On("GetPostsByTag", mock.Anything, uint(1), uint64(0), uint64(9)).Return(posts, nil)
+
+				// the page is cleared with the tag and with its posts
+				cache.On("SetWithTags", mock.Anything, "tag:go:posts:0-9", mock.Anything, mock.Anything, "tag:go", "post:1").Return(nil)
+			},
+			posts: posts,
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(repo *mocks.TagRepository, cache *mocks.CacheRepository) {
+				cache.On("Get", ctx, "tag:go:posts:0-9").Return(nil, domain.ErrNotFound)
+				repo.On("GetTagBySlug", mock.Anything, "go").Return(nil, domain.ErrNotFound)
+			},
+			err: domain.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := mocks.NewTagRepository(t)
+			cache := mocks.NewCacheRepository(t)
+			tc.mocks(repo, cache)
+
+			tagService := NewTagService(repo, cache, nil)
+
+			posts, err := tagService.GetPostsByTag(ctx, "go", 0, 9)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.posts, posts)
+		})
+	}
+}
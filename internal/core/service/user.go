@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"log/slog"
+	"slices"
+	"time"
 
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
@@ -9,188 +12,355 @@ import (
 )
 
 type UserService struct {
-	repo  port.UserRepository
-	cache port.CacheRepository
+	repo        port.UserRepository
+	postRepo    port.PostRepository
+	commentRepo port.CommentRepository
+	followRepo  port.FollowRepository
+	// bookmarkRepo and feedRepo hold the references to deleted users and posts
+	bookmarkRepo port.BookmarkRepository
+	feedRepo     port.FeedRepository
+	tx           port.Transactor
+	events       port.EventBus
+	cache        port.CacheRepository
+	userCache    *util.CacheAside[cachedUser]
+	usersCache   *util.CacheAside[[]domain.UserResponse]
+	// follow counts share the user's tag, following invalidates it
+	followCountsCache *util.CacheAside[domain.FollowCounts]
 }
 
-func NewUserService(repo port.UserRepository, cache port.CacheRepository) *UserService {
+func NewUserService(repo port.UserRepository, postRepo port.PostRepository, commentRepo port.CommentRepository, followRepo port.FollowRepository, bookmarkRepo port.BookmarkRepository, feedRepo port.FeedRepository, tx port.Transactor, events port.EventBus, cache port.CacheRepository, policy *util.CachePolicy) *UserService {
 	return &UserService{
 		repo,
+		postRepo,
+		commentRepo,
+		followRepo,
+		bookmarkRepo,
+		feedRepo,
+		tx,
+		events,
 		cache,
+		util.NewCacheAside(cache, "user", policy, util.EntityCacheTags[cachedUser]("user")),
+		util.NewCacheAside(cache, "users", policy, util.ListCacheTags("users", "user", userCacheID)),
+		util.NewCacheAside(cache, "follow_counts", policy, util.EntityCacheTags[domain.FollowCounts]("user")),
 	}
 }
 
-func (us *UserService) RegisterUser(ctx context.Context, user *domain.User) (*domain.UserResponse, error) {
-	// hash password
-	hashedPwd, err := util.HashPassword(user.Password)
-	if err != nil {
-		return nil, err
+// cachedUser lists the user fields kept in the cache, new user fields aren't
+// cached until they're added here
+type cachedUser struct {
+	ID         uint              `json:"id"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+	Email      string            `json:"email"`
+	Name       string            `json:"name"`
+	Role       domain.Role       `json:"role"`
+	TrustLevel domain.TrustLevel `json:"trust_level"`
+	Version    uint              `json:"version"`
+}
+
+func newCachedUser(user *domain.User) cachedUser {
+	return cachedUser{
+		ID:         user.ID,
+		CreatedAt:  user.CreatedAt,
+		UpdatedAt:  user.UpdatedAt,
+		Email:      user.Email,
+		Name:       user.Name,
+		Role:       user.Role,
+		TrustLevel: user.TrustLevel,
+		Version:    user.Version,
 	}
+}
 
-	user.Password = hashedPwd
+func (cu cachedUser) user() *domain.User {
+	return &domain.User{
+		ID:         cu.ID,
+		CreatedAt:  cu.CreatedAt,
+		UpdatedAt:  cu.UpdatedAt,
+		Email:      cu.Email,
+		Name:       cu.Name,
+		Role:       cu.Role,
+		TrustLevel: cu.TrustLevel,
+		Version:    cu.Version,
+	}
+}
+
+// RegisterUser creates the user, the repository hashes the password
+func (us *UserService) RegisterUser(ctx context.Context, user *domain.User, password string) (*domain.UserResponse, error) {
+	// create user, its event is stored in the same transaction
+	var created *domain.UserResponse
+	err := us.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = us.repo.CreateUser(ctx, user, password); err != nil {
+			return err
+		}
 
-	// set cache
-	cacheKey := util.GenerateCacheKey("user", user.ID)
-	userSerialized, err := util.Serialize(user)
+		return us.events.Publish(ctx, domain.NewEvent(domain.EventUserCreated, created.ID, created.ID))
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err := us.cache.Set(ctx, cacheKey, userSerialized, 0); err != nil {
+	// clear users cache (since new user created)
+	if err := us.cache.InvalidateTags(ctx, util.ListCacheTag("users")); err != nil {
 		return nil, err
 	}
 
-	// clear users cache (since new user created)
-	if err := us.cache.DeleteByPrefix(ctx, "users:*"); err != nil {
+	// set cache, the id is assigned on insert
+	if err := us.userCache.Set(ctx, user.ID, newCachedUser(user)); err != nil {
 		return nil, err
 	}
 
-	return us.repo.CreateUser(ctx, user)
+	return created, nil
 }
 
 func (us *UserService) GetUsers(ctx context.Context, start, end uint64) ([]domain.UserResponse, error) {
-	users := []domain.UserResponse{}
-
-	// get from cache
 	params := util.GenerateCacheKeyParams(start, end)
-	cacheKey := util.GenerateCacheKey("users", params)
-	usersCache, err := us.cache.Get(ctx, cacheKey)
-	if err == nil {
-		if err := util.Deserialize(usersCache, &users); err != nil {
-			return nil, err
+	return us.usersCache.Get(ctx, params, func(ctx context.Context) ([]domain.UserResponse, error) {
+		return us.repo.GetUsers(ctx, start, end)
+	})
+}
+
+func (us *UserService) GetUserByID(ctx context.Context, id uint) (*domain.User, error) {
+	cached, err := us.userCache.Get(ctx, id, func(ctx context.Context) (cachedUser, error) {
+		user, err := us.repo.GetUserByID(ctx, id)
+		if err != nil {
+			return cachedUser{}, err
 		}
-		return users, nil
-	}
 
-	// get from db if doesn't exist in cache
-	users, err = us.repo.GetUsers(ctx, start, end)
+		return newCachedUser(user), nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// cache gotten users from db
-	serializedUsers, err := util.Serialize(users)
+	user := cached.user()
+	return user, us.setFollowCounts(ctx, user)
+}
+
+// setFollowCounts embeds the follow counts, they're cached apart from the user
+// since following changes them without changing the user
+func (us *UserService) setFollowCounts(ctx context.Context, user *domain.User) error {
+	counts, err := us.followCountsCache.Get(ctx, user.ID, func(ctx context.Context) (domain.FollowCounts, error) {
+		counts, err := us.followRepo.CountFollows(ctx, user.ID)
+		if err != nil {
+			return domain.FollowCounts{}, err
+		}
+
+		return *counts, nil
+	})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	if err := us.cache.Set(ctx, cacheKey, serializedUsers, 0); err != nil {
+	user.FollowCounts = counts
+	return nil
+}
+
+// UpdateUser replaces the user's email and name, the password is only changed when given
+func (us *UserService) UpdateUser(ctx context.Context, id uint, ifMatch domain.IfMatch, user *domain.User, password string) (*domain.User, error) {
+	foundUser, err := us.getUserForUpdate(ctx, id, ifMatch)
+	if err != nil {
 		return nil, err
 	}
 
-	return users, nil
+	// replace user
+	foundUser.Name = user.Name
+	foundUser.Email = user.Email
+
+	return us.saveUser(ctx, foundUser, password)
 }
 
-func (us *UserService) GetUserByID(ctx context.Context, id uint) (*domain.User, error) {
-	user := &domain.User{}
+// PatchUser only updates the fields set in the patch
+func (us *UserService) PatchUser(ctx context.Context, id uint, ifMatch domain.IfMatch, patch *domain.UserPatch) (*domain.User, error) {
+	foundUser, err := us.getUserForUpdate(ctx, id, ifMatch)
+	if err != nil {
+		return nil, err
+	}
 
-	// get from cache
-	cacheKey := util.GenerateCacheKey("user", id)
+	// patch user
+	if patch.Name != nil {
+		foundUser.Name = *patch.Name
+	}
+	if patch.Email != nil {
+		foundUser.Email = *patch.Email
+	}
 
-	serialized, err := us.cache.Get(ctx, cacheKey)
-	if err == nil {
-		if err := util.Deserialize(serialized, user); err != nil {
-			return nil, err
+	var password string
+	if patch.Password != nil {
+		if len(*patch.Password) < 8 {
+			return nil, domain.ErrBadRequest
 		}
 
-		return user, nil
+		password = *patch.Password
 	}
 
-	// get from db if not in cache
-	user, err = us.repo.GetUserByID(ctx, id)
+	// required fields can't be cleared
+	if foundUser.Name == "" || foundUser.Email == "" {
+		return nil, domain.ErrBadRequest
+	}
+
+	return us.saveUser(ctx, foundUser, password)
+}
+
+func (us *UserService) getUserForUpdate(ctx context.Context, id uint, ifMatch domain.IfMatch) (*domain.User, error) {
+	// get user from db, the cache may hold a stale version
+	foundUser, err := us.repo.GetUserByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	// cache user
-	serialized, err = util.Serialize(user)
+	// reject updates based on an outdated version
+	if !ifMatch.Matches(foundUser.Version) {
+		return nil, domain.ErrPreconditionFailed
+	}
+
+	return foundUser, nil
+}
+
+func (us *UserService) saveUser(ctx context.Context, user *domain.User, password string) (*domain.User, error) {
+	// update user, its event is stored in the same transaction
+	err := us.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := us.repo.UpdateUser(ctx, user, password); err != nil {
+			return err
+		}
+
+		return us.events.Publish(ctx, domain.NewEvent(domain.EventUserUpdated, user.ID, user.ID))
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err := us.cache.Set(ctx, cacheKey, serialized, 0); err != nil {
+	// clear the user and the lists containing them
+	if err := us.cache.InvalidateTags(ctx, util.CacheTag("user", user.ID)); err != nil {
+		return nil, err
+	}
+
+	// cache updated user
+	if err := us.userCache.Set(ctx, user.ID, newCachedUser(user)); err != nil {
 		return nil, err
 	}
 
 	return user, nil
 }
 
-func (us *UserService) UpdateUser(ctx context.Context, id uint, user *domain.User) (*domain.User, error) {
-	foundUser := &domain.User{}
-
-	// get user from cache
-	cacheKey := util.GenerateCacheKey("user", id)
+// SetTrustLevel changes the user's trust level, actorID is the admin changing it
+func (us *UserService) SetTrustLevel(ctx context.Context, actorID, id uint, level domain.TrustLevel) error {
+	if level > domain.TrustedTrust {
+		return domain.ErrBadRequest
+	}
 
-	serialized, err := us.cache.Get(ctx, cacheKey)
-	if err == nil {
-		if err := util.Deserialize(serialized, foundUser); err != nil {
-			return nil, err
+	// update trust level, its event is stored in the same transaction
+	err := us.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := us.repo.UpdateTrustLevel(ctx, id, level); err != nil {
+			return err
 		}
+
+		return us.events.Publish(ctx, domain.NewEvent(domain.EventTrustLevelChanged, actorID, id))
+	})
+	if err != nil {
+		return err
 	}
 
-	// get user from db if not in cache
-	emptyUser := &domain.User{}
-	if foundUser == emptyUser {
-		foundUser, err = us.repo.GetUserByID(ctx, id)
-		if err != nil {
-			return nil, err
+	// clear the user and the lists containing them
+	return us.cache.InvalidateTags(ctx, util.CacheTag("user", id))
+}
+
+// DeleteUser deletes the user with their posts, comments, bookmarks and
+// follows in one transaction
+func (us *UserService) DeleteUser(ctx context.Context, actorID, id uint) (*domain.User, error) {
+	var (
+		user        *domain.User
+		posts       []domain.Post
+		comments    []domain.Comment
+		followerIDs []uint
+		followeeIDs []uint
+	)
+
+	err := us.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if comments, err = us.commentRepo.DeleteUserComments(ctx, id); err != nil {
+			return err
 		}
-	}
 
-	// update user
-	if user.Name != "" {
-		foundUser.Name = user.Name
-	}
-	if user.Email != "" {
-		foundUser.Email = user.Email
-	}
-	if user.Password != "" {
-		hashed, err := util.HashPassword(user.Password)
-		if err != nil {
-			return nil, err
+		if posts, err = us.postRepo.DeleteUserPosts(ctx, id); err != nil {
+			return err
 		}
 
-		foundUser.Password = hashed
-	}
+		// the posts leave every reading list
+		if err := us.bookmarkRepo.DeleteUserBookmarks(ctx, id); err != nil {
+			return err
+		}
 
-	// update user
-	if _, err := us.repo.UpdateUser(ctx, foundUser); err != nil {
-		return nil, err
-	}
+		// the user leaves the follow graph, the follow counts of both sides change
+		if followerIDs, err = us.followRepo.GetFollowerIDs(ctx, id); err != nil {
+			return err
+		}
 
-	// delete caches
-	if err := us.cache.Delete(ctx, cacheKey); err != nil {
-		return nil, err
-	}
+		if followeeIDs, err = us.followRepo.GetFolloweeIDs(ctx, id); err != nil {
+			return err
+		}
 
-	if err := us.cache.DeleteByPrefix(ctx, "users:*"); err != nil {
-		return nil, err
-	}
+		if err := us.followRepo.DeleteUserFollows(ctx, id); err != nil {
+			return err
+		}
 
-	// cache updated user
-	serialized, err = util.Serialize(foundUser)
+		if user, err = us.repo.DeleteUser(ctx, id); err != nil {
+			return err
+		}
+
+		return us.events.Publish(ctx, domain.NewEvent(domain.EventUserDeleted, actorID, id))
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err := us.cache.Set(ctx, cacheKey, serialized, 0); err != nil {
+	// clear the user, their content, the follow counts and the lists
+	// containing them at once
+	tags := []string{util.CacheTag("user", id), util.ListCacheTag("users")}
+	for _, userID := range slices.Concat(followerIDs, followeeIDs) {
+		tags = append(tags, util.CacheTag("user", userID))
+	}
+	if err := us.cache.InvalidateTags(ctx, append(tags, contentCacheTags(posts, comments)...)...); err != nil {
 		return nil, err
 	}
 
-	return foundUser, nil
+	// the feeds holding the deleted posts are rebuilt on their next read, on a
+	// best effort basis like the feeds of follows
+	for _, userID := range append(followerIDs, id) {
+		if err := us.feedRepo.DeleteFeed(ctx, userID); err != nil {
+			slog.Warn("unable to delete feed", "user_id", userID, "error", err)
+		}
+	}
+
+	return user, nil
 }
 
-func (us *UserService) DeleteUser(ctx context.Context, id uint) (*domain.User, error) {
-	// delete user from cache
-	cacheKey := util.GenerateCacheKey("user", id)
-	if err := us.cache.Delete(ctx, cacheKey); err != nil {
-		return nil, err
+// contentCacheTags returns the tags of the caches holding the deleted posts
+// and comments, the tag post lists are tagged with the posts they contain
+func contentCacheTags(posts []domain.Post, comments []domain.Comment) []string {
+	var tags []string
+
+	postIDs := map[uint]struct{}{}
+	for _, comment := range comments {
+		postIDs[comment.PostID] = struct{}{}
 	}
 
-	if err := us.cache.DeleteByPrefix(ctx, "users:*"); err != nil {
-		return nil, err
+	for _, post := range posts {
+		postIDs[post.ID] = struct{}{}
+		tags = append(tags, util.CacheTag("post", post.ID))
 	}
 
-	// delete user from db
-	return us.repo.DeleteUser(ctx, id)
+	for postID := range postIDs {
+		tags = append(tags, util.CacheTag("comments", postID))
+	}
+
+	if len(posts) > 0 {
+		tags = append(tags, util.ListCacheTag("posts"), util.ListCacheTag("tags"))
+	}
+
+	return tags
+}
+
+func userCacheID(user domain.UserResponse) any {
+	return user.ID
 }
@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"maps"
+	"slices"
 	"testing"
 	"time"
 
@@ -11,10 +14,12 @@ import (
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
 	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/util"
 	"github.com/yehezkiel1086/go-gin-hexa-archi/mocks"
+	"gorm.io/gorm"
 )
 
 type registerTestedInput struct {
-	user *domain.User
+	user     *domain.User
+	password string
 }
 
 type registerExpectedOutput struct {
@@ -31,9 +36,8 @@ func TestUserService_Register(t *testing.T) {
 	userPassword := gofakeit.Password(true, true, true, true, false, 8)
 
 	userInput := &domain.User{
-		Name:     userName,
-		Email:    userEmail,
-		Password: userPassword,
+		Name:  userName,
+		Email: userEmail,
 	}
 
 	userResponse := &domain.UserResponse{
@@ -62,22 +66,23 @@ func TestUserService_Register(t *testing.T) {
 				userRepo.
 					On("CreateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
 						return u.Email == userInput.Email && u.Name == userInput.Name
-					})).
+					}), userPassword).
 					Return(userResponse, nil).
 					Once()
 
 				cache.
-					On("Set", mock.Anything, cacheKey, mock.Anything, ttl).
+					On("InvalidateTags", mock.Anything, util.ListCacheTag("users")).
 					Return(nil).
 					Once()
 
 				cache.
-					On("DeleteByPrefix", mock.Anything, "users:*").
+					On("SetWithTags", mock.Anything, cacheKey, mock.Anything, ttl, util.CacheTag("user", 0)).
 					Return(nil).
 					Once()
 			},
 			input: registerTestedInput{
-				user: userInput,
+				user:     userInput,
+				password: userPassword,
 			},
 			expected: registerExpectedOutput{
 				user: userResponse,
@@ -90,25 +95,16 @@ func TestUserService_Register(t *testing.T) {
 				userRepo *mocks.UserRepository,
 				cache *mocks.CacheRepository,
 			) {
-				cache.
-					On("Set", mock.Anything, cacheKey, mock.Anything, ttl).
-					Return(nil).
-					Once()
-
-				cache.
-					On("DeleteByPrefix", mock.Anything, "users:*").
-					Return(nil).
-					Once()
-
 				userRepo.
 					On("CreateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
 						return u.Email == userInput.Email && u.Name == userInput.Name
-					})).
+					}), userPassword).
 					Return(nil, domain.ErrInternal).
 					Once()
 			},
 			input: registerTestedInput{
-				user: userInput,
+				user:     userInput,
+				password: userPassword,
 			},
 			expected: registerExpectedOutput{
 				user: nil,
@@ -121,25 +117,16 @@ func TestUserService_Register(t *testing.T) {
 				userRepo *mocks.UserRepository,
 				cache *mocks.CacheRepository,
 			) {
-				cache.
-					On("Set", mock.Anything, cacheKey, mock.Anything, ttl).
-					Return(nil).
-					Once()
-
-				cache.
-					On("DeleteByPrefix", mock.Anything, "users:*").
-					Return(nil).
-					Once()
-
 				userRepo.
 					On("CreateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
 						return u.Email == userInput.Email && u.Name == userInput.Name
-					})).
+					}), userPassword).
 					Return(nil, domain.ErrConflictingData).
 					Once()
 			},
 			input: registerTestedInput{
-				user: userInput,
+				user:     userInput,
+				password: userPassword,
 			},
 			expected: registerExpectedOutput{
 				user: nil,
@@ -152,13 +139,26 @@ func TestUserService_Register(t *testing.T) {
 				userRepo *mocks.UserRepository,
 				cache *mocks.CacheRepository,
 			) {
+				userRepo.
+					On("CreateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+						return u.Email == userInput.Email && u.Name == userInput.Name
+					}), userPassword).
+					Return(userResponse, nil).
+					Once()
+
+				cache.
+					On("InvalidateTags", mock.Anything, util.ListCacheTag("users")).
+					Return(nil).
+					Once()
+
 				cache.
-					On("Set", mock.Anything, cacheKey, mock.Anything, ttl).
+					On("SetWithTags", mock.Anything, cacheKey, mock.Anything, ttl, util.CacheTag("user", 0)).
 					Return(domain.ErrInternal).
 					Once()
 			},
 			input: registerTestedInput{
-				user: userInput,
+				user:     userInput,
+				password: userPassword,
 			},
 			expected: registerExpectedOutput{
 				user: nil,
@@ -166,23 +166,26 @@ func TestUserService_Register(t *testing.T) {
 			},
 		},
 		{
-			desc: "Fail_DeleteCacheByPrefix",
+			desc: "Fail_InvalidateCache",
 			mocks: func(
 				userRepo *mocks.UserRepository,
 				cache *mocks.CacheRepository,
 			) {
-				cache.
-					On("Set", mock.Anything, cacheKey, mock.Anything, ttl).
-					Return(nil).
+				userRepo.
+					On("CreateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+						return u.Email == userInput.Email && u.Name == userInput.Name
+					}), userPassword).
+					Return(userResponse, nil).
 					Once()
 
 				cache.
-					On("DeleteByPrefix", mock.Anything, "users:*").
+					On("InvalidateTags", mock.Anything, util.ListCacheTag("users")).
 					Return(domain.ErrInternal).
 					Once()
 			},
 			input: registerTestedInput{
-				user: userInput,
+				user:     userInput,
+				password: userPassword,
 			},
 			expected: registerExpectedOutput{
 				user: nil,
@@ -198,15 +201,14 @@ func TestUserService_Register(t *testing.T) {
 
 			tc.mocks(userRepo, cache)
 
-			userService := NewUserService(userRepo, cache)
+			userService := NewUserService(userRepo, new(mocks.PostRepository), new(mocks.CommentRepository), new(mocks.FollowRepository), new(mocks.BookmarkRepository), new(mocks.FeedRepository), fakeTransactor{}, newEventBusMock(), cache, nil)
 
-			// Clone input to avoid side effects (hashing) on the shared struct
+			// Clone input to avoid side effects on the shared struct
 			input := &domain.User{
-				Name:     tc.input.user.Name,
-				Email:    tc.input.user.Email,
-				Password: tc.input.user.Password,
+				Name:  tc.input.user.Name,
+				Email: tc.input.user.Email,
 			}
-			user, err := userService.RegisterUser(ctx, input)
+			user, err := userService.RegisterUser(ctx, input, tc.input.password)
 
 			assert.Equal(t, tc.expected.err, err)
 			assert.Equal(t, tc.expected.user, user)
@@ -231,7 +233,7 @@ func TestUserService_GetUsers(t *testing.T) {
 
 	params := util.GenerateCacheKeyParams(start, end)
 	cacheKey := util.GenerateCacheKey("users", params)
-	serializedUsers, _ := util.Serialize(users)
+	serializedUsers, _ := util.MarshalCacheEntry(users, 0, 0)
 
 	testCases := []struct {
 		desc     string
@@ -251,8 +253,8 @@ func TestUserService_GetUsers(t *testing.T) {
 			desc: "Success_CacheMiss",
 			mocks: func(ur *mocks.UserRepository, cr *mocks.CacheRepository) {
 				cr.On("Get", ctx, cacheKey).Return(nil, domain.ErrInternal).Once()
-				ur.On("GetUsers", ctx, start, end).Return(users, nil).Once()
-				cr.On("Set", ctx, cacheKey, mock.Anything, time.Duration(0)).Return(nil).Once()
+				ur.On("GetUsers", mock.Anything, start, end).Return(users, nil).Once()
+				cr.On("SetWithTags", mock.Anything, cacheKey, mock.Anything, time.Duration(0), util.ListCacheTag("users"), util.CacheTag("user", userResponse.ID)).Return(nil).Once()
 			},
 			expected: users,
 			err:      nil,
@@ -261,7 +263,7 @@ func TestUserService_GetUsers(t *testing.T) {
 			desc: "Fail_RepoError",
 			mocks: func(ur *mocks.UserRepository, cr *mocks.CacheRepository) {
 				cr.On("Get", ctx, cacheKey).Return(nil, domain.ErrInternal).Once()
-				ur.On("GetUsers", ctx, start, end).Return(nil, domain.ErrInternal).Once()
+				ur.On("GetUsers", mock.Anything, start, end).Return(nil, domain.ErrInternal).Once()
 			},
 			expected: nil,
 			err:      domain.ErrInternal,
@@ -274,7 +276,7 @@ func TestUserService_GetUsers(t *testing.T) {
 			cr := new(mocks.CacheRepository)
 			tc.mocks(ur, cr)
 
-			s := NewUserService(ur, cr)
+			s := NewUserService(ur, new(mocks.PostRepository), new(mocks.CommentRepository), new(mocks.FollowRepository), new(mocks.BookmarkRepository), new(mocks.FeedRepository), fakeTransactor{}, newEventBusMock(), cr, nil)
 			res, err := s.GetUsers(ctx, start, end)
 
 			assert.Equal(t, tc.err, err)
@@ -296,37 +298,47 @@ func TestUserService_GetUserByID(t *testing.T) {
 	}
 
 	cacheKey := util.GenerateCacheKey("user", id)
-	serializedUser, _ := util.Serialize(user)
+	serializedUser, _ := util.MarshalCacheEntry(newCachedUser(user), 0, 0)
+
+	countsKey := util.GenerateCacheKey("follow_counts", id)
+	counts := &domain.FollowCounts{Followers: 3, Following: 5}
+	serializedCounts, _ := util.MarshalCacheEntry(*counts, 0, 0)
+	withCounts := *user
+	withCounts.FollowCounts = *counts
 
 	testCases := []struct {
 		desc     string
-		mocks    func(*mocks.UserRepository, *mocks.CacheRepository)
+		mocks    func(*mocks.UserRepository, *mocks.FollowRepository, *mocks.CacheRepository)
 		expected *domain.User
 		err      error
 	}{
 		{
 			desc: "Success_CacheHit",
-			mocks: func(ur *mocks.UserRepository, cr *mocks.CacheRepository) {
+			mocks: func(ur *mocks.UserRepository, fr *mocks.FollowRepository, cr *mocks.CacheRepository) {
 				cr.On("Get", ctx, cacheKey).Return(serializedUser, nil).Once()
+				cr.On("Get", ctx, countsKey).Return(serializedCounts, nil).Once()
 			},
-			expected: user,
+			expected: &withCounts,
 			err:      nil,
 		},
 		{
 			desc: "Success_CacheMiss",
-			mocks: func(ur *mocks.UserRepository, cr *mocks.CacheRepository) {
+			mocks: func(ur *mocks.UserRepository, fr *mocks.FollowRepository, cr *mocks.CacheRepository) {
 				cr.On("Get", ctx, cacheKey).Return(nil, domain.ErrInternal).Once()
-				ur.On("GetUserByID", ctx, id).Return(user, nil).Once()
-				cr.On("Set", ctx, cacheKey, mock.Anything, time.Duration(0)).Return(nil).Once()
+				ur.On("GetUserByID", mock.Anything, id).Return(user, nil).Once()
+				cr.On("SetWithTags", mock.Anything, cacheKey, mock.Anything, time.Duration(0), util.CacheTag("user", id)).Return(nil).Once()
+				cr.On("Get", ctx, countsKey).Return(nil, domain.ErrInternal).Once()
+				fr.On("CountFollows", mock.Anything, id).Return(counts, nil).Once()
+				cr.On("SetWithTags", mock.Anything, countsKey, mock.Anything, time.Duration(0), util.CacheTag("user", id)).Return(nil).Once()
 			},
-			expected: user,
+			expected: &withCounts,
 			err:      nil,
 		},
 		{
 			desc: "Fail_RepoError",
-			mocks: func(ur *mocks.UserRepository, cr *mocks.CacheRepository) {
+			mocks: func(ur *mocks.UserRepository, fr *mocks.FollowRepository, cr *mocks.CacheRepository) {
 				cr.On("Get", ctx, cacheKey).Return(nil, domain.ErrInternal).Once()
-				ur.On("GetUserByID", ctx, id).Return(nil, domain.ErrInternal).Once()
+				ur.On("GetUserByID", mock.Anything, id).Return(nil, domain.ErrInternal).Once()
 			},
 			expected: nil,
 			err:      domain.ErrInternal,
@@ -336,15 +348,17 @@ func TestUserService_GetUserByID(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
 			ur := new(mocks.UserRepository)
+			fr := new(mocks.FollowRepository)
 			cr := new(mocks.CacheRepository)
-			tc.mocks(ur, cr)
+			tc.mocks(ur, fr, cr)
 
-			s := NewUserService(ur, cr)
+			s := NewUserService(ur, new(mocks.PostRepository), new(mocks.CommentRepository), fr, new(mocks.BookmarkRepository), new(mocks.FeedRepository), fakeTransactor{}, newEventBusMock(), cr, nil)
 			res, err := s.GetUserByID(ctx, id)
 
 			assert.Equal(t, tc.err, err)
 			assert.Equal(t, tc.expected, res)
 			ur.AssertExpectations(t)
+			fr.AssertExpectations(t)
 			cr.AssertExpectations(t)
 		})
 	}
@@ -361,32 +375,49 @@ func TestUserService_UpdateUser(t *testing.T) {
 	cacheKey := util.GenerateCacheKey("user", id)
 
 	testCases := []struct {
-		desc  string
-		mocks func(*mocks.UserRepository, *mocks.CacheRepository, *domain.User)
-		err   error
+		desc     string
+		password string
+		mocks    func(*mocks.UserRepository, *mocks.CacheRepository, *domain.User)
+		err      error
 	}{
 		{
 			desc: "Success",
 			mocks: func(ur *mocks.UserRepository, cr *mocks.CacheRepository, existing *domain.User) {
-				serialized, _ := util.Serialize(existing)
-				cr.On("Get", ctx, cacheKey).Return(serialized, nil).Once()
-				// Note: Implementation calls GetUserByID even if cache hit
-				ur.On("GetUserByID", ctx, id).Return(existing, nil).Once()
+				// Note: Implementation always reads from the db to compare versions
+				ur.On("GetUserByID", mock.Anything, id).Return(existing, nil).Once()
 				ur.On("UpdateUser", ctx, mock.MatchedBy(func(u *domain.User) bool {
 					return u.Name == updateInput.Name
-				})).Return(existing, nil).Once()
-				cr.On("Delete", ctx, cacheKey).Return(nil).Once()
-				cr.On("Delete", ctx, "users:*").Return(nil).Once()
-				cr.On("Set", ctx, cacheKey, mock.Anything, time.Duration(0)).Return(nil).Once()
+				}), "").Return(existing, nil).Once()
+				cr.On("InvalidateTags", ctx, util.CacheTag("user", id)).Return(nil).Once()
+				cr.On("SetWithTags", mock.Anything, cacheKey, mock.Anything, time.Duration(0), util.CacheTag("user", id)).Return(nil).Once()
 			},
 			err: nil,
 		},
+		{
+			desc:     "Success_ChangePassword",
+			password: "newpassword",
+			mocks: func(ur *mocks.UserRepository, cr *mocks.CacheRepository, existing *domain.User) {
+				// the repository hashes the new password
+				ur.On("GetUserByID", mock.Anything, id).Return(existing, nil).Once()
+				ur.On("UpdateUser", ctx, mock.Anything, "newpassword").Return(existing, nil).Once()
+				cr.On("InvalidateTags", ctx, util.CacheTag("user", id)).Return(nil).Once()
+				cr.On("SetWithTags", mock.Anything, cacheKey, mock.Anything, time.Duration(0), util.CacheTag("user", id)).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			desc: "Fail_VersionMismatch",
+			mocks: func(ur *mocks.UserRepository, cr *mocks.CacheRepository, existing *domain.User) {
+				existing.Version = 2
+				ur.On("GetUserByID", mock.Anything, id).Return(existing, nil).Once()
+			},
+			err: domain.ErrPreconditionFailed,
+		},
 		{
 			desc: "Fail_RepoUpdateError",
 			mocks: func(ur *mocks.UserRepository, cr *mocks.CacheRepository, existing *domain.User) {
-				cr.On("Get", ctx, cacheKey).Return(nil, domain.ErrInternal).Once()
-				ur.On("GetUserByID", ctx, id).Return(existing, nil).Once()
-				ur.On("UpdateUser", ctx, mock.Anything).Return(nil, domain.ErrInternal).Once()
+				ur.On("GetUserByID", mock.Anything, id).Return(existing, nil).Once()
+				ur.On("UpdateUser", ctx, mock.Anything, "").Return(nil, domain.ErrInternal).Once()
 			},
 			err: domain.ErrInternal,
 		},
@@ -399,16 +430,16 @@ func TestUserService_UpdateUser(t *testing.T) {
 
 			// Create fresh existing user for each run to avoid side effects
 			existingUser := &domain.User{
-				ID:       id,
-				Name:     gofakeit.Name(),
-				Email:    gofakeit.Email(),
-				Password: "oldpassword",
+				ID:      id,
+				Name:    gofakeit.Name(),
+				Email:   gofakeit.Email(),
+				Version: 1,
 			}
 
 			tc.mocks(ur, cr, existingUser)
 
-			s := NewUserService(ur, cr)
-			res, err := s.UpdateUser(ctx, id, updateInput)
+			s := NewUserService(ur, new(mocks.PostRepository), new(mocks.CommentRepository), new(mocks.FollowRepository), new(mocks.BookmarkRepository), new(mocks.FeedRepository), fakeTransactor{}, newEventBusMock(), cr, nil)
+			res, err := s.UpdateUser(ctx, id, domain.MatchVersion(1), updateInput, tc.password)
 
 			assert.Equal(t, tc.err, err)
 			if tc.err == nil {
@@ -420,32 +451,246 @@ func TestUserService_UpdateUser(t *testing.T) {
 	}
 }
 
-func TestUserService_DeleteUser(t *testing.T) {
+func TestUserService_PatchUser(t *testing.T) {
 	ctx := context.Background()
 	id := uint(gofakeit.Number(1, 100))
+	version := uint(1)
+
+	newName := "New Name"
+	emptyName := ""
+	newPassword := "newpassword"
+	shortPassword := "short"
+
 	cacheKey := util.GenerateCacheKey("user", id)
+
+	testCases := []struct {
+		desc    string
+		ifMatch domain.IfMatch
+		patch   *domain.UserPatch
+		mocks   func(*mocks.UserRepository, *mocks.CacheRepository, *domain.User)
+		err     error
+	}{
+		{
+			desc:    "Success",
+			ifMatch: domain.MatchVersion(version),
+			patch:   &domain.UserPatch{Name: &newName},
+			mocks: func(ur *mocks.UserRepository, cr *mocks.CacheRepository, existing *domain.User) {
+				ur.On("GetUserByID", mock.Anything, id).Return(existing, nil).Once()
+				ur.On("UpdateUser", ctx, mock.MatchedBy(func(u *domain.User) bool {
+					return u.Name == newName && u.Email == existing.Email
+				}), "").Return(existing, nil).Once()
+				cr.On("InvalidateTags", ctx, util.CacheTag("user", id)).Return(nil).Once()
+				cr.On("SetWithTags", mock.Anything, cacheKey, mock.Anything, time.Duration(0), util.CacheTag("user", id)).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			desc:    "Success_ChangePassword",
+			ifMatch: domain.MatchVersion(version),
+			patch:   &domain.UserPatch{Name: &newName, Password: &newPassword},
+			mocks: func(ur *mocks.UserRepository, cr *mocks.CacheRepository, existing *domain.User) {
+				ur.On("GetUserByID", mock.Anything, id).Return(existing, nil).Once()
+				ur.On("UpdateUser", ctx, mock.Anything, newPassword).Return(existing, nil).Once()
+				cr.On("InvalidateTags", ctx, util.CacheTag("user", id)).Return(nil).Once()
+				cr.On("SetWithTags", mock.Anything, cacheKey, mock.Anything, time.Duration(0), util.CacheTag("user", id)).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			desc:    "Fail_ShortPassword",
+			ifMatch: domain.MatchVersion(version),
+			patch:   &domain.UserPatch{Password: &shortPassword},
+			mocks: func(ur *mocks.UserRepository, cr *mocks.CacheRepository, existing *domain.User) {
+				ur.On("GetUserByID", mock.Anything, id).Return(existing, nil).Once()
+			},
+			err: domain.ErrBadRequest,
+		},
+		{
+			desc:    "Fail_ClearRequiredField",
+			ifMatch: domain.MatchVersion(version),
+			patch:   &domain.UserPatch{Name: &emptyName},
+			mocks: func(ur *mocks.UserRepository, cr *mocks.CacheRepository, existing *domain.User) {
+				ur.On("GetUserByID", mock.Anything, id).Return(existing, nil).Once()
+			},
+			err: domain.ErrBadRequest,
+		},
+		{
+			desc:    "Success_AnyVersion",
+			ifMatch: domain.IfMatch{Any: true},
+			patch:   &domain.UserPatch{Name: &newName},
+			mocks: func(ur *mocks.UserRepository, cr *mocks.CacheRepository, existing *domain.User) {
+				existing.Version = version + 1
+				ur.On("GetUserByID", mock.Anything, id).Return(existing, nil).Once()
+				ur.On("UpdateUser", ctx, mock.Anything, "").Return(existing, nil).Once()
+				cr.On("InvalidateTags", ctx, util.CacheTag("user", id)).Return(nil).Once()
+				cr.On("SetWithTags", mock.Anything, cacheKey, mock.Anything, time.Duration(0), util.CacheTag("user", id)).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			desc:    "Success_VersionInList",
+			ifMatch: domain.IfMatch{Versions: []uint{version, version + 1}},
+			patch:   &domain.UserPatch{Name: &newName},
+			mocks: func(ur *mocks.UserRepository, cr *mocks.CacheRepository, existing *domain.User) {
+				existing.Version = version + 1
+				ur.On("GetUserByID", mock.Anything, id).Return(existing, nil).Once()
+				ur.On("UpdateUser", ctx, mock.Anything, "").Return(existing, nil).Once()
+				cr.On("InvalidateTags", ctx, util.CacheTag("user", id)).Return(nil).Once()
+				cr.On("SetWithTags", mock.Anything, cacheKey, mock.Anything, time.Duration(0), util.CacheTag("user", id)).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			desc:    "Fail_VersionMismatch",
+			ifMatch: domain.MatchVersion(version),
+			patch:   &domain.UserPatch{Name: &newName},
+			mocks: func(ur *mocks.UserRepository, cr *mocks.CacheRepository, existing *domain.User) {
+				existing.Version = version + 1
+				ur.On("GetUserByID", mock.Anything, id).Return(existing, nil).Once()
+			},
+			err: domain.ErrPreconditionFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ur := new(mocks.UserRepository)
+			cr := new(mocks.CacheRepository)
+
+			existingUser := &domain.User{
+				ID:      id,
+				Name:    gofakeit.Name(),
+				Email:   gofakeit.Email(),
+				Version: version,
+			}
+
+			tc.mocks(ur, cr, existingUser)
+
+			s := NewUserService(ur, new(mocks.PostRepository), new(mocks.CommentRepository), new(mocks.FollowRepository), new(mocks.BookmarkRepository), new(mocks.FeedRepository), fakeTransactor{}, newEventBusMock(), cr, nil)
+			res, err := s.PatchUser(ctx, id, tc.ifMatch, tc.patch)
+
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				assert.Equal(t, newName, res.Name)
+			}
+			ur.AssertExpectations(t)
+			cr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_DeleteUser(t *testing.T) {
+	ctx := context.Background()
+	actorID := uint(gofakeit.Number(101, 200))
+	id := uint(gofakeit.Number(1, 100))
+	followerID := uint(gofakeit.Number(201, 300))
+	followeeID := uint(gofakeit.Number(301, 400))
 	deletedUser := &domain.User{ID: id}
 
+	post := domain.Post{Model: gorm.Model{ID: uint(gofakeit.Number(1, 100))}, UserID: id}
+	comment := domain.Comment{ID: uint(gofakeit.Number(1, 100)), PostID: post.ID, UserID: id}
+
+	type repos struct {
+		user     *mocks.UserRepository
+		post     *mocks.PostRepository
+		comment  *mocks.CommentRepository
+		follow   *mocks.FollowRepository
+		bookmark *mocks.BookmarkRepository
+		feed     *mocks.FeedRepository
+		cache    *mocks.CacheRepository
+	}
+
 	testCases := []struct {
 		desc     string
-		mocks    func(*mocks.UserRepository, *mocks.CacheRepository)
+		mocks    func(r repos)
 		expected *domain.User
 		err      error
 	}{
 		{
 			desc: "Success",
-			mocks: func(ur *mocks.UserRepository, cr *mocks.CacheRepository) {
-				cr.On("Delete", ctx, cacheKey).Return(nil).Once()
-				cr.On("Delete", ctx, "users:*").Return(nil).Once()
-				ur.On("DeleteUser", ctx, id).Return(deletedUser, nil).Once()
+			mocks: func(r repos) {
+				r.comment.On("DeleteUserComments", ctx, id).Return([]domain.Comment{comment}, nil).Once()
+				r.post.On("DeleteUserPosts", ctx, id).Return([]domain.Post{post}, nil).Once()
+				r.bookmark.On("DeleteUserBookmarks", ctx, id).Return(nil).Once()
+				r.follow.On("GetFollowerIDs", ctx, id).Return([]uint{followerID}, nil).Once()
+				r.follow.On("GetFolloweeIDs", ctx, id).Return([]uint{followeeID}, nil).Once()
+				r.follow.On("DeleteUserFollows", ctx, id).Return(nil).Once()
+				r.user.On("DeleteUser", ctx, id).Return(deletedUser, nil).Once()
+				r.cache.On("InvalidateTags", ctx,
+					util.CacheTag("user", id),
+					util.ListCacheTag("users"),
+					util.CacheTag("user", followerID),
+					util.CacheTag("user", followeeID),
+					util.CacheTag("post", post.ID),
+					util.CacheTag("comments", post.ID),
+					util.ListCacheTag("posts"),
+					util.ListCacheTag("tags"),
+				).Return(nil).Once()
+				r.feed.On("DeleteFeed", ctx, followerID).Return(nil).Once()
+				r.feed.On("DeleteFeed", ctx, id).Return(nil).Once()
 			},
 			expected: deletedUser,
 			err:      nil,
 		},
 		{
-			desc: "Fail_CacheDeleteError",
-			mocks: func(ur *mocks.UserRepository, cr *mocks.CacheRepository) {
-				cr.On("Delete", ctx, cacheKey).Return(domain.ErrInternal).Once()
+			desc: "Success_FeedErrorIgnored",
+			mocks: func(r repos) {
+				r.comment.On("DeleteUserComments", ctx, id).Return(nil, nil).Once()
+				r.post.On("DeleteUserPosts", ctx, id).Return(nil, nil).Once()
+				r.bookmark.On("DeleteUserBookmarks", ctx, id).Return(nil).Once()
+				r.follow.On("GetFollowerIDs", ctx, id).Return(nil, nil).Once()
+				r.follow.On("GetFolloweeIDs", ctx, id).Return(nil, nil).Once()
+				r.follow.On("DeleteUserFollows", ctx, id).Return(nil).Once()
+				r.user.On("DeleteUser", ctx, id).Return(deletedUser, nil).Once()
+				r.cache.On("InvalidateTags", ctx, util.CacheTag("user", id), util.ListCacheTag("users")).Return(nil).Once()
+				r.feed.On("DeleteFeed", ctx, id).Return(domain.ErrInternal).Once()
+			},
+			expected: deletedUser,
+			err:      nil,
+		},
+		{
+			desc: "Fail_RepoErrorRollsBack",
+			mocks: func(r repos) {
+				r.comment.On("DeleteUserComments", ctx, id).Return([]domain.Comment{comment}, nil).Once()
+				r.post.On("DeleteUserPosts", ctx, id).Return(nil, domain.ErrInternal).Once()
+			},
+			expected: nil,
+			err:      domain.ErrInternal,
+		},
+		{
+			desc: "Fail_BookmarkErrorRollsBack",
+			mocks: func(r repos) {
+				r.comment.On("DeleteUserComments", ctx, id).Return(nil, nil).Once()
+				r.post.On("DeleteUserPosts", ctx, id).Return(nil, nil).Once()
+				r.bookmark.On("DeleteUserBookmarks", ctx, id).Return(domain.ErrInternal).Once()
+			},
+			expected: nil,
+			err:      domain.ErrInternal,
+		},
+		{
+			desc: "Fail_FollowErrorRollsBack",
+			mocks: func(r repos) {
+				r.comment.On("DeleteUserComments", ctx, id).Return(nil, nil).Once()
+				r.post.On("DeleteUserPosts", ctx, id).Return(nil, nil).Once()
+				r.bookmark.On("DeleteUserBookmarks", ctx, id).Return(nil).Once()
+				r.follow.On("GetFollowerIDs", ctx, id).Return(nil, nil).Once()
+				r.follow.On("GetFolloweeIDs", ctx, id).Return(nil, nil).Once()
+				r.follow.On("DeleteUserFollows", ctx, id).Return(domain.ErrInternal).Once()
+			},
+			expected: nil,
+			err:      domain.ErrInternal,
+		},
+		{
+			desc: "Fail_CacheInvalidateError",
+			mocks: func(r repos) {
+				r.comment.On("DeleteUserComments", ctx, id).Return(nil, nil).Once()
+				r.post.On("DeleteUserPosts", ctx, id).Return(nil, nil).Once()
+				r.bookmark.On("DeleteUserBookmarks", ctx, id).Return(nil).Once()
+				r.follow.On("GetFollowerIDs", ctx, id).Return(nil, nil).Once()
+				r.follow.On("GetFolloweeIDs", ctx, id).Return(nil, nil).Once()
+				r.follow.On("DeleteUserFollows", ctx, id).Return(nil).Once()
+				r.user.On("DeleteUser", ctx, id).Return(deletedUser, nil).Once()
+				r.cache.On("InvalidateTags", ctx, util.CacheTag("user", id), util.ListCacheTag("users")).Return(domain.ErrInternal).Once()
 			},
 			expected: nil,
 			err:      domain.ErrInternal,
@@ -454,17 +699,74 @@ func TestUserService_DeleteUser(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
-			ur := new(mocks.UserRepository)
-			cr := new(mocks.CacheRepository)
-			tc.mocks(ur, cr)
+			r := repos{
+				user:     new(mocks.UserRepository),
+				post:     new(mocks.PostRepository),
+				comment:  new(mocks.CommentRepository),
+				follow:   new(mocks.FollowRepository),
+				bookmark: new(mocks.BookmarkRepository),
+				feed:     new(mocks.FeedRepository),
+				cache:    new(mocks.CacheRepository),
+			}
+			tc.mocks(r)
+
+			// the event records the admin who deleted the user
+			events := new(mocks.EventBus)
+			events.On("Publish", ctx, mock.MatchedBy(func(event domain.Event) bool {
+				return event.Type == domain.EventUserDeleted && event.ActorID == actorID && event.UserID == id
+			})).Return(nil).Maybe()
 
-			s := NewUserService(ur, cr)
-			res, err := s.DeleteUser(ctx, id)
+			s := NewUserService(r.user, r.post, r.comment, r.follow, r.bookmark, r.feed, fakeTransactor{}, events, r.cache, nil)
+			res, err := s.DeleteUser(ctx, actorID, id)
 
 			assert.Equal(t, tc.err, err)
 			assert.Equal(t, tc.expected, res)
-			ur.AssertExpectations(t)
-			cr.AssertExpectations(t)
+			r.user.AssertExpectations(t)
+			r.post.AssertExpectations(t)
+			r.comment.AssertExpectations(t)
+			r.follow.AssertExpectations(t)
+			r.bookmark.AssertExpectations(t)
+			r.feed.AssertExpectations(t)
+			r.cache.AssertExpectations(t)
+			if tc.err == nil {
+				events.AssertNumberOfCalls(t, "Publish", 1)
+			}
 		})
 	}
 }
+
+// events are published after the tested behaviour, so any publish is accepted
+func newEventBusMock() *mocks.EventBus {
+	events := new(mocks.EventBus)
+	events.On("Publish", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	return events
+}
+
+// fakeTransactor runs fn without a transaction, the repositories are mocked
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestCachedUser(t *testing.T) {
+	user := &domain.User{
+		ID:         1,
+		Email:      gofakeit.Email(),
+		Name:       gofakeit.Name(),
+		Role:       domain.AdminRole,
+		TrustLevel: domain.TrustedTrust,
+		Version:    3,
+	}
+
+	data, err := util.Serialize(newCachedUser(user))
+	assert.NoError(t, err)
+
+	// only the allow-listed fields are cached
+	var fields map[string]any
+	assert.NoError(t, json.Unmarshal(data, &fields))
+	assert.ElementsMatch(t, []string{"id", "created_at", "updated_at", "email", "name", "role", "trust_level", "version"}, slices.Collect(maps.Keys(fields)))
+
+	assert.Equal(t, user, newCachedUser(user).user())
+}
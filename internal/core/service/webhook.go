@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/util"
+)
+
+const (
+	// deliveries sent per DeliverDue call
+	webhookBatchSize = 50
+
+	// retries back off exponentially from the base delay up to the max delay
+	webhookBaseDelay = 30 * time.Second
+	webhookMaxDelay  = 6 * time.Hour
+
+	// claimed deliveries are sent again after the lease if the replica sending
+	// them stops before recording the result
+	webhookClaimLease = 15 * time.Minute
+)
+
+type WebhookService struct {
+	// maxAttempts is how often a delivery is sent before it's marked failed
+	maxAttempts int
+	repo        port.WebhookRepository
+	sender      port.WebhookSender
+}
+
+func NewWebhookService(maxAttempts int, repo port.WebhookRepository, sender port.WebhookSender) *WebhookService {
+	return &WebhookService{
+		maxAttempts,
+		repo,
+		sender,
+	}
+}
+
+func (ws *WebhookService) CreateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	if err := validateWebhook(webhook); err != nil {
+		return nil, err
+	}
+
+	// generate a secret if none is given
+	if webhook.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		webhook.Secret = secret
+	}
+
+	return ws.repo.CreateWebhook(ctx, webhook)
+}
+
+func (ws *WebhookService) GetWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	return ws.repo.GetWebhooks(ctx)
+}
+
+func (ws *WebhookService) GetWebhookByID(ctx context.Context, id uint) (*domain.Webhook, error) {
+	return ws.repo.GetWebhookByID(ctx, id)
+}
+
+// UpdateWebhook replaces the webhook's settings, the secret is kept when not given
+func (ws *WebhookService) UpdateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	if err := validateWebhook(webhook); err != nil {
+		return nil, err
+	}
+
+	foundWebhook, err := ws.repo.GetWebhookByID(ctx, webhook.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	foundWebhook.URL = webhook.URL
+	foundWebhook.EventTypes = webhook.EventTypes
+	foundWebhook.Active = webhook.Active
+	if webhook.Secret != "" {
+		foundWebhook.Secret = webhook.Secret
+	}
+
+	return ws.repo.UpdateWebhook(ctx, foundWebhook)
+}
+
+func (ws *WebhookService) DeleteWebhook(ctx context.Context, id uint) error {
+	return ws.repo.DeleteWebhook(ctx, id)
+}
+
+func (ws *WebhookService) GetDeliveries(ctx context.Context, webhookID uint, start, end uint64) ([]domain.WebhookDelivery, error) {
+	if _, err := ws.repo.GetWebhookByID(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	return ws.repo.GetDeliveries(ctx, webhookID, start, end)
+}
+
+func (ws *WebhookService) Redeliver(ctx context.Context, deliveryID uint) (*domain.WebhookDelivery, error) {
+	delivery, err := ws.repo.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	redelivery := domain.WebhookDelivery{
+		WebhookID:     delivery.WebhookID,
+		EventType:     delivery.EventType,
+		Payload:       delivery.Payload,
+		Status:        domain.DeliveryPending,
+		NextAttemptAt: time.Now(),
+	}
+
+	deliveries := []domain.WebhookDelivery{redelivery}
+	if err := ws.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		return nil, err
+	}
+
+	return &deliveries[0], nil
+}
+
+func (ws *WebhookService) HandleEvent(ctx context.Context, event domain.Event) error {
+	if !isWebhookEvent(event.Type) {
+		return nil
+	}
+
+	webhooks, err := ws.repo.GetActiveWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	// queue a delivery for each subscribed webhook, the dispatcher sends them
+	now := time.Now()
+	var deliveries []domain.WebhookDelivery
+	for _, webhook := range webhooks {
+		if !webhook.Subscribed(event.Type) {
+			continue
+		}
+
+		deliveries = append(deliveries, domain.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventType:     event.Type,
+			Payload:       string(payload),
+			Status:        domain.DeliveryPending,
+			NextAttemptAt: now,
+		})
+	}
+
+	return ws.repo.CreateDeliveries(ctx, deliveries)
+}
+
+func (ws *WebhookService) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := ws.repo.ClaimDueDeliveries(ctx, time.Now(), webhookClaimLease, webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range deliveries {
+		if err := ws.deliver(ctx, &deliveries[i]); err != nil {
+			return i, err
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// deliver sends the delivery once and records the result, failed deliveries
+// are retried with exponential backoff until they run out of attempts
+func (ws *WebhookService) deliver(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	delivery.Attempts++
+	delivery.ResponseStatus = 0
+	delivery.ResponseBody = ""
+	delivery.Error = ""
+
+	if delivery.Webhook == nil || !delivery.Webhook.Active {
+		delivery.Status = domain.DeliveryFailed
+		delivery.Error = "webhook is inactive"
+		_, err := ws.repo.UpdateDelivery(ctx, delivery)
+		return err
+	}
+
+	timestamp := time.Now().Unix()
+	body := []byte(delivery.Payload)
+
+	res, err := ws.sender.Send(ctx, &domain.WebhookRequest{
+		URL: delivery.Webhook.URL,
+		Headers: map[string]string{
+			"X-Webhook-Event":     string(delivery.EventType),
+			"X-Webhook-Delivery":  strconv.FormatUint(uint64(delivery.ID), 10),
+			"X-Webhook-Timestamp": strconv.FormatInt(timestamp, 10),
+			"X-Webhook-Signature": "sha256=" + util.SignPayload(delivery.Webhook.Secret, timestamp, body),
+		},
+		Body: body,
+	})
+
+	switch {
+	case err != nil:
+		delivery.Error = err.Error()
+	case res.Status < 200 || res.Status >= 300:
+		delivery.ResponseStatus = res.Status
+		delivery.ResponseBody = res.Body
+		delivery.Error = fmt.Sprintf("unexpected response status %d", res.Status)
+	default:
+		delivery.ResponseStatus = res.Status
+		delivery.ResponseBody = res.Body
+	}
+
+	now := time.Now()
+	switch {
+	case delivery.Error == "":
+		delivery.Status = domain.DeliverySucceeded
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= ws.maxAttempts:
+		delivery.Status = domain.DeliveryFailed
+		slog.Warn("webhook delivery failed", "delivery_id", delivery.ID, "webhook_id", delivery.WebhookID, "error", delivery.Error)
+	default:
+		delivery.NextAttemptAt = now.Add(webhookBackoff(delivery.Attempts))
+	}
+
+	_, err = ws.repo.UpdateDelivery(ctx, delivery)
+	return err
+}
+
+// webhookBackoff returns the delay before the next attempt, doubling per attempt
+func webhookBackoff(attempts int) time.Duration {
+	delay := webhookBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= webhookMaxDelay {
+			return webhookMaxDelay
+		}
+	}
+
+	return delay
+}
+
+func validateWebhook(webhook *domain.Webhook) error {
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return domain.ErrBadRequest
+	}
+
+	if len(webhook.EventTypes) == 0 {
+		return domain.ErrBadRequest
+	}
+
+	for _, eventType := range webhook.EventTypes {
+		if !isWebhookEvent(eventType) {
+			return domain.ErrBadRequest
+		}
+	}
+
+	return nil
+}
+
+func isWebhookEvent(eventType domain.EventType) bool {
+	for _, t := range domain.WebhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/mocks"
+)
+
+func TestWebhookService_UpdateWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		desc     string
+		secret   string
+		expected string
+	}{
+		{
+			desc:     "EmptySecretKept",
+			secret:   "",
+			expected: "current",
+		},
+		{
+			desc:     "SecretRotated",
+			secret:   "rotated",
+			expected: "rotated",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := mocks.NewWebhookRepository(t)
+			repo.On("GetWebhookByID", ctx, uint(1)).Return(&domain.Webhook{ID: 1, Secret: "current"}, nil).Once()
+			repo.On("UpdateWebhook", ctx, mock.Anything).Return(func(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+				return webhook, nil
+			}).Once()
+
+			s := NewWebhookService(3, repo, nil)
+			webhook, err := s.UpdateWebhook(ctx, &domain.Webhook{
+				ID:         1,
+				URL:        "https://example.com/hook",
+				Secret:     tc.secret,
+				EventTypes: []domain.EventType{domain.EventPostCreated},
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, webhook.Secret)
+		})
+	}
+}
+
+func TestWebhook_SecretNotSerialized(t *testing.T) {
+	webhook := &domain.Webhook{ID: 1, Secret: "secret"}
+
+	data, err := json.Marshal(webhook)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "secret")
+
+	// the secret is only returned once the webhook is created
+	data, err = json.Marshal(domain.CreatedWebhook{Webhook: webhook, Secret: webhook.Secret})
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"secret":"secret"`)
+}
@@ -1,14 +1,34 @@
 package util
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
 )
 
 func GenerateCacheKey(prefix string, param any) string {
 	return fmt.Sprintf("%s:%v", prefix, param)
 }
 
+// CacheTag names the tag of an entity, e.g. post:42
+func CacheTag(family string, id any) string {
+	return GenerateCacheKey(family, id)
+}
+
+// ListCacheTag names the tag of every cached list of a family, e.g. list:posts
+func ListCacheTag(family string) string {
+	return GenerateCacheKey("list", family)
+}
+
 func GenerateCacheKeyParams(params ...any) string {
 	var str string
 
@@ -31,3 +51,202 @@ func Serialize(data any) ([]byte, error) {
 func Deserialize(data []byte, res any) error {
 	return json.Unmarshal(data, res)
 }
+
+// CacheCodec encodes cached values, its name is part of the cache keys so
+// values written with another codec are never read
+type CacheCodec interface {
+	Name() string
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// NewCacheCodec returns the codec named json or msgpack, a +gzip suffix
+// compresses the encoded values
+func NewCacheCodec(name string) (CacheCodec, error) {
+	name, compressed := strings.CutSuffix(name, "+gzip")
+
+	var codec CacheCodec
+	switch name {
+	case "", "json":
+		codec = JSONCodec{}
+	case "msgpack":
+		codec = MsgpackCodec{}
+	default:
+		return nil, errors.New("invalid cache codec: " + name)
+	}
+
+	if compressed {
+		codec = GzipCodec{codec}
+	}
+
+	return codec, nil
+}
+
+type JSONCodec struct{}
+
+func (JSONCodec) Name() string {
+	return "json"
+}
+
+func (JSONCodec) Marshal(v any) ([]byte, error) {
+	return Serialize(v)
+}
+
+func (JSONCodec) Unmarshal(data []byte, v any) error {
+	return Deserialize(data, v)
+}
+
+// MsgpackCodec uses the json struct tags, so fields are named and omitted the same way
+type MsgpackCodec struct{}
+
+func (MsgpackCodec) Name() string {
+	return "msgpack"
+}
+
+func (MsgpackCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (MsgpackCodec) Unmarshal(data []byte, v any) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(v)
+}
+
+// GzipCodec compresses the values encoded by another codec
+type GzipCodec struct {
+	Codec CacheCodec
+}
+
+func (gc GzipCodec) Name() string {
+	return gc.Codec.Name() + "+gzip"
+}
+
+func (gc GzipCodec) Marshal(v any) ([]byte, error) {
+	data, err := gc.Codec.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (gc GzipCodec) Unmarshal(data []byte, v any) error {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	data, err = io.ReadAll(zr)
+	if err != nil {
+		return err
+	}
+
+	return gc.Codec.Unmarshal(data, v)
+}
+
+// NamespacedCache prefixes the keys with the app name so apps can share a
+// redis. Cached values and tags are prefixed with the version too, so a
+// deploy starts with an empty cache instead of reading the previous
+// version's shapes. Hashes and sets hold counters carried across versions.
+type NamespacedCache struct {
+	cache     port.CacheRepository
+	namespace string
+	versioned string
+}
+
+func NewNamespacedCache(cache port.CacheRepository, namespace, version string) *NamespacedCache {
+	return &NamespacedCache{
+		cache:     cache,
+		namespace: namespace + ":",
+		versioned: namespace + ":" + version + ":",
+	}
+}
+
+func (nc *NamespacedCache) Set(ctx context.Context, key string, val []byte, ttl time.Duration) error {
+	return nc.cache.Set(ctx, nc.versioned+key, val, ttl)
+}
+
+func (nc *NamespacedCache) Get(ctx context.Context, key string) ([]byte, error) {
+	return nc.cache.Get(ctx, nc.versioned+key)
+}
+
+func (nc *NamespacedCache) Delete(ctx context.Context, key string) error {
+	return nc.cache.Delete(ctx, nc.versioned+key)
+}
+
+func (nc *NamespacedCache) SetWithTags(ctx context.Context, key string, val []byte, ttl time.Duration, tags ...string) error {
+	return nc.cache.SetWithTags(ctx, nc.versioned+key, val, ttl, nc.prefixAll(tags)...)
+}
+
+func (nc *NamespacedCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	return nc.cache.InvalidateTags(ctx, nc.prefixAll(tags)...)
+}
+
+func (nc *NamespacedCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	return nc.cache.DeleteByPrefix(ctx, nc.versioned+prefix)
+}
+
+func (nc *NamespacedCache) HIncrBy(ctx context.Context, key, field string, incr int64) (int64, error) {
+	return nc.cache.HIncrBy(ctx, nc.namespace+key, field, incr)
+}
+
+func (nc *NamespacedCache) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	return nc.cache.HGetAll(ctx, nc.namespace+key)
+}
+
+func (nc *NamespacedCache) HGetAllMulti(ctx context.Context, keys ...string) ([]map[string]string, error) {
+	namespaced := make([]string, len(keys))
+	for i, key := range keys {
+		namespaced[i] = nc.namespace + key
+	}
+
+	return nc.cache.HGetAllMulti(ctx, namespaced...)
+}
+
+func (nc *NamespacedCache) HSetNX(ctx context.Context, key string, fields map[string]int64) error {
+	return nc.cache.HSetNX(ctx, nc.namespace+key, fields)
+}
+
+func (nc *NamespacedCache) HReplace(ctx context.Context, key string, fields map[string]int64) error {
+	return nc.cache.HReplace(ctx, nc.namespace+key, fields)
+}
+
+func (nc *NamespacedCache) SAdd(ctx context.Context, key string, members ...string) error {
+	return nc.cache.SAdd(ctx, nc.namespace+key, members...)
+}
+
+func (nc *NamespacedCache) SPop(ctx context.Context, key string, count int64) ([]string, error) {
+	return nc.cache.SPop(ctx, nc.namespace+key, count)
+}
+
+func (nc *NamespacedCache) Close() error {
+	return nc.cache.Close()
+}
+
+func (nc *NamespacedCache) prefixAll(tags []string) []string {
+	prefixed := make([]string, len(tags))
+	for i, tag := range tags {
+		prefixed[i] = nc.versioned + tag
+	}
+
+	return prefixed
+}
@@ -0,0 +1,291 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/config"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/port"
+	"golang.org/x/sync/singleflight"
+)
+
+// earlyRefreshBeta scales the early refresh probability, above 1 refreshes earlier
+const earlyRefreshBeta = 1.0
+
+// CachePolicy holds the TTLs of the cache key families and the codec of the
+// cached values, families without a TTL use the default TTL
+type CachePolicy struct {
+	ttls        map[string]time.Duration
+	defaultTTL  time.Duration
+	negativeTTL time.Duration
+	codec       CacheCodec
+}
+
+// NewCachePolicy parses TTLs given in seconds as "family=ttl,family=ttl"
+func NewCachePolicy(conf *config.Cache) (*CachePolicy, error) {
+	codec, err := NewCacheCodec(conf.Codec)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &CachePolicy{
+		ttls:  map[string]time.Duration{},
+		codec: codec,
+	}
+
+	for _, pair := range strings.Split(conf.TTLs, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		family, ttl, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, errors.New("invalid cache ttl: " + pair)
+		}
+
+		seconds, err := strconv.Atoi(strings.TrimSpace(ttl))
+		if err != nil {
+			return nil, err
+		}
+		policy.ttls[strings.TrimSpace(family)] = time.Duration(seconds) * time.Second
+	}
+
+	if conf.DefaultTTL != "" {
+		seconds, err := strconv.Atoi(conf.DefaultTTL)
+		if err != nil {
+			return nil, err
+		}
+		policy.defaultTTL = time.Duration(seconds) * time.Second
+	}
+
+	if conf.NegativeTTL != "" {
+		seconds, err := strconv.Atoi(conf.NegativeTTL)
+		if err != nil {
+			return nil, err
+		}
+		policy.negativeTTL = time.Duration(seconds) * time.Second
+	}
+
+	return policy, nil
+}
+
+// TTL is how long the family's values are cached, 0 never expires them
+func (cp *CachePolicy) TTL(family string) time.Duration {
+	if cp == nil {
+		return 0
+	}
+
+	if ttl, ok := cp.ttls[family]; ok {
+		return ttl
+	}
+
+	return cp.defaultTTL
+}
+
+// Codec encodes the cached values, JSON unless configured otherwise
+func (cp *CachePolicy) Codec() CacheCodec {
+	if cp == nil || cp.codec == nil {
+		return JSONCodec{}
+	}
+
+	return cp.codec
+}
+
+// NegativeTTL is how long not found results are cached, 0 disables negative caching
+func (cp *CachePolicy) NegativeTTL() time.Duration {
+	if cp == nil {
+		return 0
+	}
+
+	return cp.negativeTTL
+}
+
+// cacheEntry wraps a cached value with what's needed for negative caching and early refresh
+type cacheEntry[T any] struct {
+	Value    T    `json:"value"`
+	NotFound bool `json:"not_found,omitempty"`
+
+	// StoredAt is missing in values not written as entries, they are treated as misses
+	StoredAt int64 `json:"stored_at"`
+
+	// ExpiresAt is 0 for entries without a TTL, Delta is how long loading took, both in ms
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+	Delta     int64 `json:"delta,omitempty"`
+}
+
+// MarshalCacheEntry encodes a value as read by CacheAside with the default
+// codec, delta is how long loading it took
+func MarshalCacheEntry[T any](val T, ttl, delta time.Duration) ([]byte, error) {
+	return marshalCacheEntry(JSONCodec{}, cacheEntry[T]{Value: val}, ttl, delta)
+}
+
+func marshalCacheEntry[T any](codec CacheCodec, entry cacheEntry[T], ttl, delta time.Duration) ([]byte, error) {
+	now := time.Now()
+	entry.StoredAt = now.UnixMilli()
+	entry.Delta = delta.Milliseconds()
+	if ttl > 0 {
+		entry.ExpiresAt = now.Add(ttl).UnixMilli()
+	}
+
+	return codec.Marshal(entry)
+}
+
+// refreshEarly decides whether to reload before the entry expires, the closer it
+// is to expiring and the slower it is to load the likelier (XFetch)
+func (e *cacheEntry[T]) refreshEarly() bool {
+	if e.ExpiresAt == 0 || e.Delta == 0 {
+		return false
+	}
+
+	gap := -float64(e.Delta) * earlyRefreshBeta * math.Log(rand.Float64())
+	return float64(time.Now().UnixMilli())+gap >= float64(e.ExpiresAt)
+}
+
+// CacheTags returns the tags a cached value is invalidated by
+type CacheTags[T any] func(param any, val T) []string
+
+// EntityCacheTags tags an entity cached by id with its own tag
+func EntityCacheTags[T any](family string) CacheTags[T] {
+	return func(param any, _ T) []string {
+		return []string{CacheTag(family, param)}
+	}
+}
+
+// ListCacheTags tags a list with the family's list tag and the tag of each item,
+// so changing an item invalidates the lists containing it
+func ListCacheTags[T any](family, itemFamily string, id func(T) any) CacheTags[[]T] {
+	return func(_ any, vals []T) []string {
+		tags := []string{ListCacheTag(family)}
+		for _, val := range vals {
+			tags = append(tags, CacheTag(itemFamily, id(val)))
+		}
+
+		return tags
+	}
+}
+
+// CacheAside caches the values of a key family, concurrent misses of a key
+// share one load so a cold key doesn't send every request to the db
+type CacheAside[T any] struct {
+	cache       port.CacheRepository
+	family      string
+	ttl         time.Duration
+	negativeTTL time.Duration
+	codec       CacheCodec
+	tags        CacheTags[T]
+	group       singleflight.Group
+}
+
+// NewCacheAside returns the cache of a key family, tags may be nil for untagged values
+func NewCacheAside[T any](cache port.CacheRepository, family string, policy *CachePolicy, tags CacheTags[T]) *CacheAside[T] {
+	return &CacheAside[T]{
+		cache:       cache,
+		family:      family,
+		ttl:         policy.TTL(family),
+		negativeTTL: policy.NegativeTTL(),
+		codec:       policy.Codec(),
+		tags:        tags,
+	}
+}
+
+func (ca *CacheAside[T]) Key(param any) string {
+	return GenerateCacheKey(ca.family, param)
+}
+
+// Get returns the cached value, or loads and caches it. domain.ErrNotFound from
+// load is cached too when negative caching is enabled
+func (ca *CacheAside[T]) Get(ctx context.Context, param any, load func(ctx context.Context) (T, error)) (T, error) {
+	key := ca.Key(param)
+
+	serialized, err := ca.cache.Get(ctx, key)
+	if err != nil {
+		return ca.load(ctx, param, key, load)
+	}
+
+	var entry cacheEntry[T]
+	if err := ca.codec.Unmarshal(serialized, &entry); err != nil || entry.StoredAt == 0 {
+		return ca.load(ctx, param, key, load)
+	}
+
+	if entry.refreshEarly() {
+		val, err := ca.load(ctx, param, key, load)
+		if err == nil || errors.Is(err, domain.ErrNotFound) {
+			return val, err
+		}
+		// keep serving the cached entry if reloading fails
+	}
+
+	if entry.NotFound {
+		var zero T
+		return zero, domain.ErrNotFound
+	}
+
+	return entry.Value, nil
+}
+
+func (ca *CacheAside[T]) Set(ctx context.Context, param any, val T) error {
+	return ca.set(ctx, param, ca.Key(param), cacheEntry[T]{Value: val}, ca.ttl, 0)
+}
+
+func (ca *CacheAside[T]) Delete(ctx context.Context, param any) error {
+	return ca.cache.Delete(ctx, ca.Key(param))
+}
+
+// load runs once for concurrent misses of a key. The shared load outlives the
+// request starting it, so its cancellation doesn't fail the other waiters, and
+// the loaded value is returned even if caching it fails
+func (ca *CacheAside[T]) load(ctx context.Context, param any, key string, load func(ctx context.Context) (T, error)) (T, error) {
+	res := ca.group.DoChan(key, func() (any, error) {
+		ctx := context.WithoutCancel(ctx)
+		start := time.Now()
+
+		val, err := load(ctx)
+		if errors.Is(err, domain.ErrNotFound) && ca.negativeTTL > 0 {
+			if err := ca.set(ctx, param, key, cacheEntry[T]{NotFound: true}, ca.negativeTTL, 0); err != nil {
+				slog.Warn("unable to cache missing value", "key", key, "error", err)
+			}
+			return nil, err
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := ca.set(ctx, param, key, cacheEntry[T]{Value: val}, ca.ttl, time.Since(start)); err != nil {
+			slog.Warn("unable to cache value", "key", key, "error", err)
+		}
+
+		return val, nil
+	})
+
+	var zero T
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case r := <-res:
+		if r.Err != nil {
+			return zero, r.Err
+		}
+
+		return r.Val.(T), nil
+	}
+}
+
+func (ca *CacheAside[T]) set(ctx context.Context, param any, key string, entry cacheEntry[T], ttl, delta time.Duration) error {
+	serialized, err := marshalCacheEntry(ca.codec, entry, ttl, delta)
+	if err != nil {
+		return err
+	}
+
+	if ca.tags == nil {
+		return ca.cache.Set(ctx, key, serialized, ttl)
+	}
+
+	return ca.cache.SetWithTags(ctx, key, serialized, ttl, ca.tags(param, entry.Value)...)
+}
@@ -0,0 +1,272 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/adapter/config"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/mocks"
+	"gorm.io/gorm"
+)
+
+func TestNewCachePolicy(t *testing.T) {
+	policy, err := NewCachePolicy(&config.Cache{TTLs: "post=600, users=60", NegativeTTL: "30"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 600*time.Second, policy.TTL("post"))
+	assert.Equal(t, 60*time.Second, policy.TTL("users"))
+	assert.Equal(t, time.Duration(0), policy.TTL("category"))
+	assert.Equal(t, 30*time.Second, policy.NegativeTTL())
+	assert.Equal(t, "json", policy.Codec().Name())
+
+	// families without a ttl use the default
+	policy, err = NewCachePolicy(&config.Cache{TTLs: "post=0", DefaultTTL: "300", Codec: "msgpack+gzip"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, time.Duration(0), policy.TTL("post"))
+	assert.Equal(t, 300*time.Second, policy.TTL("category"))
+	assert.Equal(t, "msgpack+gzip", policy.Codec().Name())
+
+	_, err = NewCachePolicy(&config.Cache{TTLs: "post"})
+	assert.Error(t, err)
+
+	_, err = NewCachePolicy(&config.Cache{Codec: "xml"})
+	assert.Error(t, err)
+}
+
+func TestCacheAside_Get(t *testing.T) {
+	ctx := context.Background()
+	key := GenerateCacheKey("post", 1)
+	post := domain.Post{Title: "Hello"}
+
+	hit, _ := MarshalCacheEntry(post, 0, 0)
+	legacy, _ := Serialize(post)
+
+	testCases := []struct {
+		desc      string
+		mocks     func(*mocks.CacheRepository)
+		loadErr   error
+		expected  domain.Post
+		err       error
+		loadCalls int32
+	}{
+		{
+			desc: "Hit",
+			mocks: func(cr *mocks.CacheRepository) {
+				cr.On("Get", ctx, key).Return(hit, nil).Once()
+			},
+			expected:  post,
+			loadCalls: 0,
+		},
+		{
+			desc: "Miss",
+			mocks: func(cr *mocks.CacheRepository) {
+				cr.On("Get", ctx, key).Return(nil, domain.ErrNotFound).Once()
+				cr.On("Set", mock.Anything, key, mock.Anything, 10*time.Minute).Return(nil).Once()
+			},
+			expected:  post,
+			loadCalls: 1,
+		},
+		{
+			desc: "LegacyValueIsMiss",
+			mocks: func(cr *mocks.CacheRepository) {
+				cr.On("Get", ctx, key).Return(legacy, nil).Once()
+				cr.On("Set", mock.Anything, key, mock.Anything, 10*time.Minute).Return(nil).Once()
+			},
+			expected:  post,
+			loadCalls: 1,
+		},
+		{
+			desc: "SetFails",
+			mocks: func(cr *mocks.CacheRepository) {
+				cr.On("Get", ctx, key).Return(nil, domain.ErrNotFound).Once()
+				cr.On("Set", mock.Anything, key, mock.Anything, 10*time.Minute).Return(errors.New("connection refused")).Once()
+			},
+			expected:  post,
+			loadCalls: 1,
+		},
+		{
+			desc: "NotFoundIsCached",
+			mocks: func(cr *mocks.CacheRepository) {
+				cr.On("Get", ctx, key).Return(nil, domain.ErrNotFound).Once()
+				cr.On("Set", mock.Anything, key, mock.MatchedBy(func(val []byte) bool {
+					var entry cacheEntry[domain.Post]
+					return Deserialize(val, &entry) == nil && entry.NotFound
+				}), 30*time.Second).Return(nil).Once()
+			},
+			loadErr:   domain.ErrNotFound,
+			err:       domain.ErrNotFound,
+			loadCalls: 1,
+		},
+	}
+
+	policy := &CachePolicy{ttls: map[string]time.Duration{"post": 10 * time.Minute}, negativeTTL: 30 * time.Second}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cr := new(mocks.CacheRepository)
+			tc.mocks(cr)
+
+			var calls atomic.Int32
+			ca := NewCacheAside[domain.Post](cr, "post", policy, nil)
+			res, err := ca.Get(ctx, 1, func(ctx context.Context) (domain.Post, error) {
+				calls.Add(1)
+				return post, tc.loadErr
+			})
+
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				assert.Equal(t, tc.expected, res)
+			}
+			assert.Equal(t, tc.loadCalls, calls.Load())
+			cr.AssertExpectations(t)
+		})
+	}
+
+	t.Run("NegativeHit", func(t *testing.T) {
+		negative, _ := marshalCacheEntry(JSONCodec{}, cacheEntry[domain.Post]{NotFound: true}, 30*time.Second, 0)
+
+		cr := new(mocks.CacheRepository)
+		cr.On("Get", ctx, key).Return(negative, nil).Once()
+
+		ca := NewCacheAside[domain.Post](cr, "post", policy, nil)
+		_, err := ca.Get(ctx, 1, func(ctx context.Context) (domain.Post, error) {
+			t.Fatal("negative hit must not load")
+			return domain.Post{}, nil
+		})
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		cr.AssertExpectations(t)
+	})
+}
+
+func TestCacheAside_Get_CoalescesMisses(t *testing.T) {
+	ctx := context.Background()
+	key := GenerateCacheKey("post", 1)
+
+	cr := new(mocks.CacheRepository)
+	cr.On("Get", ctx, key).Return(nil, domain.ErrNotFound)
+	cr.On("Set", mock.Anything, key, mock.Anything, time.Duration(0)).Return(nil)
+
+	ca := NewCacheAside[domain.Post](cr, "post", nil, nil)
+
+	const requests = 10
+	release := make(chan struct{})
+	var started sync.WaitGroup
+	var calls atomic.Int32
+
+	var wg sync.WaitGroup
+	for range requests {
+		started.Add(1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+
+			post, err := ca.Get(ctx, 1, func(ctx context.Context) (domain.Post, error) {
+				calls.Add(1)
+				<-release
+				return domain.Post{Title: "Hello"}, nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "Hello", post.Title)
+		}()
+	}
+
+	// let every request reach the load before it returns
+	started.Wait()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestCacheAside_Get_CancelledCaller(t *testing.T) {
+	key := GenerateCacheKey("post", 1)
+
+	cr := new(mocks.CacheRepository)
+	cr.On("Get", mock.Anything, key).Return(nil, domain.ErrNotFound)
+	cr.On("Set", mock.Anything, key, mock.Anything, time.Duration(0)).Return(nil)
+
+	ca := NewCacheAside[domain.Post](cr, "post", nil, nil)
+
+	release := make(chan struct{})
+	load := func(ctx context.Context) (domain.Post, error) {
+		<-release
+		return domain.Post{Title: "Hello"}, ctx.Err()
+	}
+
+	// the first caller gives up while the load it started is running
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := ca.Get(ctx, 1, load)
+		first <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	second := make(chan domain.Post)
+	go func() {
+		post, err := ca.Get(context.Background(), 1, load)
+		assert.NoError(t, err)
+		second <- post
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, <-first, context.Canceled)
+
+	// the shared load isn't cancelled with it, the other caller gets the post
+	close(release)
+	assert.Equal(t, "Hello", (<-second).Title)
+}
+
+func TestCacheEntry_RefreshEarly(t *testing.T) {
+	now := time.Now().UnixMilli()
+
+	testCases := []struct {
+		desc     string
+		entry    cacheEntry[string]
+		expected bool
+	}{
+		{desc: "NoTTL", entry: cacheEntry[string]{Delta: 100}, expected: false},
+		{desc: "FarFromExpiry", entry: cacheEntry[string]{ExpiresAt: now + time.Hour.Milliseconds(), Delta: 1}, expected: false},
+		{desc: "Expired", entry: cacheEntry[string]{ExpiresAt: now - 1, Delta: 1}, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.entry.refreshEarly())
+		})
+	}
+}
+
+func TestCacheAside_Tags(t *testing.T) {
+	ctx := context.Background()
+	posts := []domain.Post{{Model: gorm.Model{ID: 1}}, {Model: gorm.Model{ID: 2}}}
+	key := GenerateCacheKey("posts", "0-10")
+
+	// lists are tagged with their items so changing one clears the lists containing it
+	cr := new(mocks.CacheRepository)
+	cr.On("Get", ctx, key).Return(nil, domain.ErrNotFound).Once()
+	cr.On("SetWithTags", mock.Anything, key, mock.Anything, time.Duration(0), "list:posts", "post:1", "post:2").Return(nil).Once()
+
+	ca := NewCacheAside(cr, "posts", nil, ListCacheTags("posts", "post", func(post domain.Post) any {
+		return post.ID
+	}))
+	res, err := ca.Get(ctx, "0-10", func(ctx context.Context) ([]domain.Post, error) {
+		return posts, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, posts, res)
+	cr.AssertExpectations(t)
+}
@@ -0,0 +1,70 @@
+package util
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/mocks"
+	"gorm.io/gorm"
+)
+
+func TestCacheCodec(t *testing.T) {
+	publishedAt := time.Now().UTC().Truncate(time.Millisecond)
+	post := domain.Post{
+		Model:       gorm.Model{ID: 1, CreatedAt: publishedAt},
+		Title:       "Hello",
+		Content:     "World",
+		Published:   true,
+		PublishedAt: &publishedAt,
+		Tags:        []domain.Tag{{Name: "Go", Slug: "go"}},
+	}
+
+	for _, name := range []string{"json", "msgpack", "json+gzip", "msgpack+gzip"} {
+		t.Run(name, func(t *testing.T) {
+			codec, err := NewCacheCodec(name)
+			assert.NoError(t, err)
+			assert.Equal(t, name, codec.Name())
+
+			data, err := codec.Marshal(cacheEntry[domain.Post]{Value: post, StoredAt: 1})
+			assert.NoError(t, err)
+
+			var entry cacheEntry[domain.Post]
+			assert.NoError(t, codec.Unmarshal(data, &entry))
+			assert.Equal(t, int64(1), entry.StoredAt)
+			assert.Equal(t, post.ID, entry.Value.ID)
+			assert.Equal(t, post.Title, entry.Value.Title)
+			assert.Equal(t, post.Tags, entry.Value.Tags)
+			assert.True(t, post.PublishedAt.Equal(*entry.Value.PublishedAt))
+		})
+	}
+}
+
+func TestNamespacedCache(t *testing.T) {
+	ctx := context.Background()
+
+	cr := new(mocks.CacheRepository)
+	cr.On("SetWithTags", ctx, "blog:v2:post:1", mock.Anything, time.Minute, "blog:v2:post:1").Return(nil).Once()
+	cr.On("InvalidateTags", ctx, "blog:v2:list:posts").Return(nil).Once()
+	cr.On("Get", ctx, "blog:v2:post:1").Return([]byte("{}"), nil).Once()
+
+	// counters are carried across versions
+	cr.On("HIncrBy", ctx, "blog:reactions:1", "like", int64(1)).Return(int64(1), nil).Once()
+	cr.On("SAdd", ctx, "blog:reactions:dirty", "1").Return(nil).Once()
+
+	nc := NewNamespacedCache(cr, "blog", "v2")
+	assert.NoError(t, nc.SetWithTags(ctx, "post:1", []byte("{}"), time.Minute, "post:1"))
+	assert.NoError(t, nc.InvalidateTags(ctx, "list:posts"))
+
+	_, err := nc.Get(ctx, "post:1")
+	assert.NoError(t, err)
+
+	_, err = nc.HIncrBy(ctx, "reactions:1", "like", 1)
+	assert.NoError(t, err)
+	assert.NoError(t, nc.SAdd(ctx, "reactions:dirty", "1"))
+
+	cr.AssertExpectations(t)
+}
@@ -0,0 +1,32 @@
+package util
+
+import (
+	"encoding/base64"
+	"strconv"
+
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+// EncodeCursor returns an opaque pagination cursor pointing after the given id
+func EncodeCursor(id uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(uint64(id), 10)))
+}
+
+// DecodeCursor returns the id of the cursor, an empty cursor is the first page
+func DecodeCursor(cursor string) (uint, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, domain.ErrInvalidQuery
+	}
+
+	id, err := strconv.ParseUint(string(decoded), 10, 64)
+	if err != nil {
+		return 0, domain.ErrInvalidQuery
+	}
+
+	return uint(id), nil
+}
@@ -0,0 +1,25 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/yehezkiel1086/go-gin-hexa-archi/internal/core/domain"
+)
+
+// DiffRevisions returns a unified diff between two post revisions
+func DiffRevisions(from, to *domain.PostRevision) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(revisionText(from)),
+		B:        difflib.SplitLines(revisionText(to)),
+		FromFile: fmt.Sprintf("revision/%d", from.ID),
+		ToFile:   fmt.Sprintf("revision/%d", to.ID),
+		FromDate: from.CreatedAt.Format("2006-01-02 15:04:05"),
+		ToDate:   to.CreatedAt.Format("2006-01-02 15:04:05"),
+		Context:  3,
+	})
+}
+
+func revisionText(revision *domain.PostRevision) string {
+	return revision.Title + "\n\n" + revision.Content + "\n"
+}
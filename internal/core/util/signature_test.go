@@ -0,0 +1,34 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignPayload(t *testing.T) {
+	body := []byte(`{"type":"post.created"}`)
+
+	testCases := []struct {
+		desc      string
+		secret    string
+		timestamp int64
+		expected  string
+	}{
+		{desc: "Known", secret: "secret", timestamp: 1700000000, expected: "940aae20b21e2ae05723769a4b6cbc0d698667f1a365172e808ba11f2a409b78"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, SignPayload(tc.secret, tc.timestamp, body))
+		})
+	}
+
+	t.Run("DifferentSecret", func(t *testing.T) {
+		assert.NotEqual(t, SignPayload("secret", 1700000000, body), SignPayload("other", 1700000000, body))
+	})
+
+	t.Run("DifferentTimestamp", func(t *testing.T) {
+		assert.NotEqual(t, SignPayload("secret", 1700000000, body), SignPayload("secret", 1700000001, body))
+	})
+}
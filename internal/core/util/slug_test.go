@@ -0,0 +1,29 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		input    string
+		expected string
+	}{
+		{desc: "Spaces", input: "Hello World", expected: "hello-world"},
+		{desc: "Punctuation", input: "What's new in Go 1.24?!", expected: "what-s-new-in-go-1-24"},
+		{desc: "Accents", input: "Crème brûlée à la carte", expected: "creme-brulee-a-la-carte"},
+		{desc: "SpecialLetters", input: "Straße Ærø Łódź", expected: "strasse-aero-lodz"},
+		{desc: "Cyrillic", input: "Привет мир", expected: "privet-mir"},
+		{desc: "TrimDashes", input: "  --Hello--  ", expected: "hello"},
+		{desc: "Untransliterable", input: "日本語", expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Slugify(tc.input))
+		})
+	}
+}
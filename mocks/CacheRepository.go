@@ -99,6 +99,217 @@ func (_m *CacheRepository) Get(ctx context.Context, key string) ([]byte, error)
 	return r0, r1
 }
 
+// HGetAll provides a mock function with given fields: ctx, key
+func (_m *CacheRepository) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HGetAll")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string]string, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string]string); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HGetAllMulti provides a mock function with given fields: ctx, keys
+func (_m *CacheRepository) HGetAllMulti(ctx context.Context, keys ...string) ([]map[string]string, error) {
+	_va := make([]interface{}, len(keys))
+	for _i := range keys {
+		_va[_i] = keys[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HGetAllMulti")
+	}
+
+	var r0 []map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) ([]map[string]string, error)); ok {
+		return rf(ctx, keys...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) []map[string]string); ok {
+		r0 = rf(ctx, keys...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = rf(ctx, keys...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HIncrBy provides a mock function with given fields: ctx, key, field, incr
+func (_m *CacheRepository) HIncrBy(ctx context.Context, key string, field string, incr int64) (int64, error) {
+	ret := _m.Called(ctx, key, field, incr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HIncrBy")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) (int64, error)); ok {
+		return rf(ctx, key, field, incr)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) int64); ok {
+		r0 = rf(ctx, key, field, incr)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = rf(ctx, key, field, incr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HReplace provides a mock function with given fields: ctx, key, fields
+func (_m *CacheRepository) HReplace(ctx context.Context, key string, fields map[string]int64) error {
+	ret := _m.Called(ctx, key, fields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HReplace")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]int64) error); ok {
+		r0 = rf(ctx, key, fields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HSetNX provides a mock function with given fields: ctx, key, fields
+func (_m *CacheRepository) HSetNX(ctx context.Context, key string, fields map[string]int64) error {
+	ret := _m.Called(ctx, key, fields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HSetNX")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]int64) error); ok {
+		r0 = rf(ctx, key, fields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvalidateTags provides a mock function with given fields: ctx, tags
+func (_m *CacheRepository) InvalidateTags(ctx context.Context, tags ...string) error {
+	_va := make([]interface{}, len(tags))
+	for _i := range tags {
+		_va[_i] = tags[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) error); ok {
+		r0 = rf(ctx, tags...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SAdd provides a mock function with given fields: ctx, key, members
+func (_m *CacheRepository) SAdd(ctx context.Context, key string, members ...string) error {
+	_va := make([]interface{}, len(members))
+	for _i := range members {
+		_va[_i] = members[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, key)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SAdd")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...string) error); ok {
+		r0 = rf(ctx, key, members...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SPop provides a mock function with given fields: ctx, key, count
+func (_m *CacheRepository) SPop(ctx context.Context, key string, count int64) ([]string, error) {
+	ret := _m.Called(ctx, key, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SPop")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) ([]string, error)); ok {
+		return rf(ctx, key, count)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []string); ok {
+		r0 = rf(ctx, key, count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, key, count)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Set provides a mock function with given fields: ctx, key, val, ttl
 func (_m *CacheRepository) Set(ctx context.Context, key string, val []byte, ttl time.Duration) error {
 	ret := _m.Called(ctx, key, val, ttl)
@@ -117,6 +328,31 @@ func (_m *CacheRepository) Set(ctx context.Context, key string, val []byte, ttl
 	return r0
 }
 
+// SetWithTags provides a mock function with given fields: ctx, key, val, ttl, tags
+func (_m *CacheRepository) SetWithTags(ctx context.Context, key string, val []byte, ttl time.Duration, tags ...string) error {
+	_va := make([]interface{}, len(tags))
+	for _i := range tags {
+		_va[_i] = tags[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, key, val, ttl)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetWithTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, time.Duration, ...string) error); ok {
+		r0 = rf(ctx, key, val, ttl, tags...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewCacheRepository creates a new instance of CacheRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCacheRepository(t interface {
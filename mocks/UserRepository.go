@@ -14,9 +14,9 @@ type UserRepository struct {
 	mock.Mock
 }
 
-// CreateUser provides a mock function with given fields: ctx, user
-func (_m *UserRepository) CreateUser(ctx context.Context, user *domain.User) (*domain.UserResponse, error) {
-	ret := _m.Called(ctx, user)
+// CreateUser provides a mock function with given fields: ctx, user, password
+func (_m *UserRepository) CreateUser(ctx context.Context, user *domain.User, password string) (*domain.UserResponse, error) {
+	ret := _m.Called(ctx, user, password)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateUser")
@@ -24,19 +24,19 @@ func (_m *UserRepository) CreateUser(ctx context.Context, user *domain.User) (*d
 
 	var r0 *domain.UserResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) (*domain.UserResponse, error)); ok {
-		return rf(ctx, user)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, string) (*domain.UserResponse, error)); ok {
+		return rf(ctx, user, password)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) *domain.UserResponse); ok {
-		r0 = rf(ctx, user)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, string) *domain.UserResponse); ok {
+		r0 = rf(ctx, user, password)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.UserResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *domain.User) error); ok {
-		r1 = rf(ctx, user)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.User, string) error); ok {
+		r1 = rf(ctx, user, password)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -164,9 +164,27 @@ func (_m *UserRepository) GetUsers(ctx context.Context, start uint64, stop uint6
 	return r0, r1
 }
 
-// UpdateUser provides a mock function with given fields: ctx, user
-func (_m *UserRepository) UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
-	ret := _m.Called(ctx, user)
+// UpdateTrustLevel provides a mock function with given fields: ctx, id, level
+func (_m *UserRepository) UpdateTrustLevel(ctx context.Context, id uint, level domain.TrustLevel) error {
+	ret := _m.Called(ctx, id, level)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTrustLevel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, domain.TrustLevel) error); ok {
+		r0 = rf(ctx, id, level)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUser provides a mock function with given fields: ctx, user, password
+func (_m *UserRepository) UpdateUser(ctx context.Context, user *domain.User, password string) (*domain.User, error) {
+	ret := _m.Called(ctx, user, password)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateUser")
@@ -174,19 +192,49 @@ func (_m *UserRepository) UpdateUser(ctx context.Context, user *domain.User) (*d
 
 	var r0 *domain.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) (*domain.User, error)); ok {
-		return rf(ctx, user)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, string) (*domain.User, error)); ok {
+		return rf(ctx, user, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, string) *domain.User); ok {
+		r0 = rf(ctx, user, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.User, string) error); ok {
+		r1 = rf(ctx, user, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyPassword provides a mock function with given fields: ctx, email, password
+func (_m *UserRepository) VerifyPassword(ctx context.Context, email string, password string) (*domain.User, error) {
+	ret := _m.Called(ctx, email, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyPassword")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.User, error)); ok {
+		return rf(ctx, email, password)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) *domain.User); ok {
-		r0 = rf(ctx, user)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.User); ok {
+		r0 = rf(ctx, email, password)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *domain.User) error); ok {
-		r1 = rf(ctx, user)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, password)
 	} else {
 		r1 = ret.Error(1)
 	}
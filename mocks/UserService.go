@@ -14,9 +14,9 @@ type UserService struct {
 	mock.Mock
 }
 
-// DeleteUser provides a mock function with given fields: ctx, id
-func (_m *UserService) DeleteUser(ctx context.Context, id uint) (*domain.User, error) {
-	ret := _m.Called(ctx, id)
+// DeleteUser provides a mock function with given fields: ctx, actorID, id
+func (_m *UserService) DeleteUser(ctx context.Context, actorID uint, id uint) (*domain.User, error) {
+	ret := _m.Called(ctx, actorID, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteUser")
@@ -24,19 +24,19 @@ func (_m *UserService) DeleteUser(ctx context.Context, id uint) (*domain.User, e
 
 	var r0 *domain.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint) (*domain.User, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (*domain.User, error)); ok {
+		return rf(ctx, actorID, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint) *domain.User); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) *domain.User); ok {
+		r0 = rf(ctx, actorID, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, actorID, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -104,9 +104,39 @@ func (_m *UserService) GetUsers(ctx context.Context, start uint64, stop uint64)
 	return r0, r1
 }
 
-// RegisterUser provides a mock function with given fields: ctx, user
-func (_m *UserService) RegisterUser(ctx context.Context, user *domain.User) (*domain.UserResponse, error) {
-	ret := _m.Called(ctx, user)
+// PatchUser provides a mock function with given fields: ctx, id, ifMatch, patch
+func (_m *UserService) PatchUser(ctx context.Context, id uint, ifMatch domain.IfMatch, patch *domain.UserPatch) (*domain.User, error) {
+	ret := _m.Called(ctx, id, ifMatch, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchUser")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, domain.IfMatch, *domain.UserPatch) (*domain.User, error)); ok {
+		return rf(ctx, id, ifMatch, patch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, domain.IfMatch, *domain.UserPatch) *domain.User); ok {
+		r0 = rf(ctx, id, ifMatch, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, domain.IfMatch, *domain.UserPatch) error); ok {
+		r1 = rf(ctx, id, ifMatch, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegisterUser provides a mock function with given fields: ctx, user, password
+func (_m *UserService) RegisterUser(ctx context.Context, user *domain.User, password string) (*domain.UserResponse, error) {
+	ret := _m.Called(ctx, user, password)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RegisterUser")
@@ -114,19 +144,19 @@ func (_m *UserService) RegisterUser(ctx context.Context, user *domain.User) (*do
 
 	var r0 *domain.UserResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) (*domain.UserResponse, error)); ok {
-		return rf(ctx, user)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, string) (*domain.UserResponse, error)); ok {
+		return rf(ctx, user, password)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) *domain.UserResponse); ok {
-		r0 = rf(ctx, user)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, string) *domain.UserResponse); ok {
+		r0 = rf(ctx, user, password)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.UserResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *domain.User) error); ok {
-		r1 = rf(ctx, user)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.User, string) error); ok {
+		r1 = rf(ctx, user, password)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -134,9 +164,27 @@ func (_m *UserService) RegisterUser(ctx context.Context, user *domain.User) (*do
 	return r0, r1
 }
 
-// UpdateUser provides a mock function with given fields: ctx, id, user
-func (_m *UserService) UpdateUser(ctx context.Context, id uint, user *domain.User) (*domain.User, error) {
-	ret := _m.Called(ctx, id, user)
+// SetTrustLevel provides a mock function with given fields: ctx, actorID, id, level
+func (_m *UserService) SetTrustLevel(ctx context.Context, actorID uint, id uint, level domain.TrustLevel) error {
+	ret := _m.Called(ctx, actorID, id, level)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTrustLevel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, domain.TrustLevel) error); ok {
+		r0 = rf(ctx, actorID, id, level)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUser provides a mock function with given fields: ctx, id, ifMatch, user, password
+func (_m *UserService) UpdateUser(ctx context.Context, id uint, ifMatch domain.IfMatch, user *domain.User, password string) (*domain.User, error) {
+	ret := _m.Called(ctx, id, ifMatch, user, password)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateUser")
@@ -144,19 +192,19 @@ func (_m *UserService) UpdateUser(ctx context.Context, id uint, user *domain.Use
 
 	var r0 *domain.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint, *domain.User) (*domain.User, error)); ok {
-		return rf(ctx, id, user)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, domain.IfMatch, *domain.User, string) (*domain.User, error)); ok {
+		return rf(ctx, id, ifMatch, user, password)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint, *domain.User) *domain.User); ok {
-		r0 = rf(ctx, id, user)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, domain.IfMatch, *domain.User, string) *domain.User); ok {
+		r0 = rf(ctx, id, ifMatch, user, password)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint, *domain.User) error); ok {
-		r1 = rf(ctx, id, user)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, domain.IfMatch, *domain.User, string) error); ok {
+		r1 = rf(ctx, id, ifMatch, user, password)
 	} else {
 		r1 = ret.Error(1)
 	}